@@ -4,11 +4,20 @@ package main
 import (
 	"context"
 	"lite-api/internal/app"
+	"lite-api/internal/client"
+	"lite-api/internal/client/breaker"
+	"lite-api/internal/client/cache"
+	"lite-api/internal/client/coalesce"
 	"lite-api/internal/client/hotelbeds"
+	"lite-api/internal/content"
+	"lite-api/internal/currency"
+	"lite-api/internal/mapping"
 	"lite-api/internal/pkg/cli"
 	"lite-api/internal/pkg/log"
 	"lite-api/internal/pkg/server"
+	"lite-api/internal/pricing"
 	"lite-api/internal/service/hotel"
+	"lite-api/internal/supplier"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -20,11 +29,159 @@ import (
 	"go.nhat.io/clock"
 )
 
-func start(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {
+func start(cfg cli.Config, logger *slog.Logger) {
 	realClock := clock.New()
-	hotelbedsClient := hotelbeds.NewHotelBeds(hotelbedsHost, hotelbedsApiKey, hotelbedsSecret, realClock, logger)
-	hotelsService := hotel.NewHotelService(hotelbedsClient, logger)
-	hotelApp := app.NewHotel(appMode, hotelsService, logger)
+	accounts, err := hotelbeds.LoadAccounts(cfg.HotelbedsAccountsPath)
+	if err != nil {
+		logger.Error("error loading hotelbeds accounts", "err", err)
+		os.Exit(1)
+	}
+	logger.Info("hotelbeds accounts loaded", "path", cfg.HotelbedsAccountsPath, "accounts", accounts.Len())
+
+	hotelbedsOpts := hotelbeds.Options{
+		Retry: hotelbeds.RetryPolicy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			BaseDelay:   cfg.RetryBaseDelay,
+			MaxDelay:    cfg.RetryMaxDelay,
+			Jitter:      cfg.RetryJitter,
+			BudgetRatio: cfg.RetryBudget,
+		},
+		RateLimit: hotelbeds.RateLimit{
+			PerSecond: cfg.RateLimitPerSecond,
+			Burst:     cfg.RateLimitBurst,
+			PerDay:    cfg.DailyQuota,
+		},
+		Transport: hotelbeds.Transport{
+			MaxIdleConns:        cfg.MaxIdleConns,
+			MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+			MaxConnsPerHost:     cfg.MaxConnsPerHost,
+			IdleConnTimeout:     cfg.IdleConnTimeout,
+			TLSHandshakeTimeout: cfg.TLSHandshakeTimeout,
+			ProxyURL:            cfg.HotelbedsProxyURL,
+			CABundle:            cfg.HotelbedsCABundle,
+		},
+		Timeouts: hotelbeds.Timeouts{
+			Search:  cfg.HotelbedsSearchTimeout,
+			Booking: cfg.HotelbedsBookingTimeout,
+		},
+		Environments: cfg.HotelbedsEnvironments,
+		Accounts:     accounts,
+	}
+
+	hotelbedsCli, err := hotelbeds.NewHotelBeds(cfg.HotelbedsHost, cfg.HotelbedsApiKey, cfg.HotelbedsSecret,
+		hotelbedsOpts, realClock, logger)
+	if err != nil {
+		logger.Error("error creating hotelbeds client", "err", err)
+		os.Exit(1)
+	}
+
+	// The Content API quota is separate from the Booking API one and is only tracked.
+	contentOpts := hotelbedsOpts
+	contentOpts.RateLimit = hotelbeds.RateLimit{}
+	contentCli, err := hotelbeds.NewContent(cfg.HotelbedsHost, cfg.HotelbedsApiKey, cfg.HotelbedsSecret,
+		contentOpts, realClock, logger)
+	if err != nil {
+		logger.Error("error creating hotelbeds content client", "err", err)
+		os.Exit(1)
+	}
+
+	var hotelbedsClient client.HotelBeds = hotelbedsCli
+
+	health := app.Health{Quotas: map[string]app.Quota{
+		"hotelbeds":         hotelbedsCli,
+		"hotelbeds-content": contentCli,
+	}}
+	if cfg.CircuitFailureRate > 0 {
+		hotelbedsBreaker := breaker.NewBreaker(hotelbedsClient, "hotelbeds", breaker.Config{
+			Window:      cfg.CircuitWindow,
+			MinRequests: cfg.CircuitMinRequests,
+			FailureRate: cfg.CircuitFailureRate,
+			CoolDown:    cfg.CircuitCoolDown,
+			Unguarded:   hotelbedsCli.ForeignEnvironments(),
+		}, realClock, logger)
+		hotelbedsClient = hotelbedsBreaker
+		health.Circuits = append(health.Circuits, hotelbedsBreaker)
+	}
+
+	hotelbedsClient = coalesce.NewCoalescer(hotelbedsClient)
+	if cfg.SearchCacheTTL > 0 {
+		hotelbedsClient = cache.NewCache(hotelbedsClient, cfg.SearchCacheTTL, cfg.SearchCacheMaxBytes, realClock)
+		logger.Info("availability cache enabled", "ttl", cfg.SearchCacheTTL, "maxBytes", cfg.SearchCacheMaxBytes)
+	}
+
+	// Search results are served without hotel names rather than failing when the content store cannot be read.
+	var directory hotel.Directory
+	contentDirectory, err := content.LoadDirectory(cfg.ContentStorePath, logger)
+	if err != nil {
+		logger.Warn("error loading content store, search results are not enriched", "err", err)
+	} else {
+		directory = contentDirectory
+		logger.Info("content store loaded", "path", cfg.ContentStorePath, "hotels", contentDirectory.Len())
+	}
+
+	// Without a rates file only hotels priced in the requested currency are returned.
+	var rates hotel.ExchangeRates
+	fileRates, err := currency.LoadFileRates(cfg.ExchangeRatesPath, logger)
+	if err != nil {
+		logger.Warn("error loading exchange rates, prices are not converted", "err", err)
+	} else {
+		rates = fileRates
+		logger.Info("exchange rates loaded", "path", cfg.ExchangeRatesPath, "currencies", fileRates.Len())
+	}
+
+	// Selling at net prices because of a broken rules file would lose the margin, the rules must load.
+	pricingRules, err := pricing.LoadRules(cfg.PricingRulesPath)
+	if err != nil {
+		logger.Error("error loading pricing rules", "err", err)
+		os.Exit(1)
+	}
+	logger.Info("pricing rules loaded", "path", cfg.PricingRulesPath, "rules", pricingRules.Len())
+
+	registry := supplier.NewRegistry()
+	if err := registry.Register(supplier.NewHotelbeds(hotelbedsClient, supplier.HotelbedsConfig{
+		BatchSize:   cfg.SearchBatchSize,
+		Concurrency: cfg.SearchConcurrency,
+	})); err != nil {
+		logger.Error("error registering supplier", "err", err)
+		os.Exit(1)
+	}
+
+	suppliers, err := registry.Enabled(cfg.Suppliers)
+	if err != nil {
+		logger.Error("error enabling suppliers", "err", err)
+		os.Exit(1)
+	}
+	logger.Info("suppliers enabled", "suppliers", cfg.Suppliers)
+
+	// Once configured, the mappings must load: lite API hotel ids sent as supplier codes would search other hotels.
+	var mapper hotel.Mapper
+	var hotelMappings *mapping.Mappings
+	if cfg.HotelMappingsPath != "" {
+		path, read := cfg.HotelMappingsPath, mapping.ReadFile
+		if path == cli.HotelMappingsFromStore {
+			path, read = cfg.ContentStorePath, content.ReadMappings
+		}
+
+		hotelMappings, err = mapping.Load(path, read, logger)
+		if err != nil {
+			logger.Error("error loading hotel mappings", "err", err)
+			os.Exit(1)
+		}
+
+		mapper = hotelMappings
+		logger.Info("hotel mappings loaded", "path", path, "mappings", hotelMappings.Len())
+	}
+
+	hotelsService := hotel.NewHotelService(hotelbedsClient, suppliers, mapper, contentCli, directory, rates,
+		pricingRules, hotel.Config{
+			CursorTTL:      cfg.SearchCursorTTL,
+			MaxCursorBytes: cfg.SearchCursorMaxBytes,
+		}, logger)
+	hotelApp := app.NewHotel(cfg.AppMode, hotelsService, logger, health, cfg.AdminToken, app.SupplierConfigPolicy{
+		Environments: cfg.SupplierConfigEnvironments,
+		Accounts:     cfg.SupplierConfigAccounts,
+		ApiKeys:      cfg.SupplierConfigApiKeys,
+	})
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -42,8 +199,80 @@ func start(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret str
 		cancel()
 	}()
 
+	if contentDirectory != nil {
+		go contentDirectory.Watch(ctx, cfg.ContentReloadInterval)
+	}
+
+	if fileRates != nil {
+		go fileRates.Watch(ctx, cfg.ExchangeRatesReloadInterval)
+	}
+
+	if hotelMappings != nil {
+		go hotelMappings.Watch(ctx, cfg.HotelMappingsReloadInterval)
+	}
+
 	handler := hotelApp.RegisterRoutes()
-	server.ServeHTTP(ctx, appPort, handler)
+	server.ServeHTTP(ctx, cfg.AppPort, handler)
+}
+
+// syncContent copies the Hotelbeds Content API lists into the local content store.
+func syncContent(cfg cli.SyncConfig, logger *slog.Logger) error {
+	realClock := clock.New()
+	// A sync sends one request after the other, failed pages are retried without a budget.
+	contentOpts := hotelbeds.Options{
+		Retry: hotelbeds.RetryPolicy{
+			MaxAttempts: cli.DefaultRetryMaxAttempts,
+			BaseDelay:   cli.DefaultRetryBaseDelay,
+			MaxDelay:    cli.DefaultRetryMaxDelay,
+			Jitter:      cli.DefaultRetryJitter,
+		},
+		Timeouts: hotelbeds.Timeouts{Search: cfg.Timeout},
+	}
+	contentCli, err := hotelbeds.NewContent(cfg.HotelbedsHost, cfg.HotelbedsApiKey, cfg.HotelbedsSecret,
+		contentOpts, realClock, logger)
+	if err != nil {
+		return err
+	}
+
+	store, err := content.Open(cfg.StorePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := store.Close(); err != nil {
+			logger.Error("error closing content store", "err", err)
+		}
+	}()
+
+	if cfg.MappingsPath != "" {
+		rows, err := mapping.ReadFile(cfg.MappingsPath)
+		if err != nil {
+			return err
+		}
+
+		// Mappings are checked before replacing the imported ones, the server would refuse to load them.
+		if _, err := mapping.NewTable(rows); err != nil {
+			return err
+		}
+
+		if err := store.PutMappings(rows); err != nil {
+			return err
+		}
+		logger.Info("hotel mappings imported", "path", cfg.MappingsPath, "mappings", len(rows))
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	report, err := content.NewSyncer(contentCli, store, realClock, logger).Sync(ctx, content.SyncOptions{
+		Language: cfg.Language,
+		PageSize: cfg.PageSize,
+		Full:     cfg.Full,
+	})
+	logger.Info("content sync finished", "report", report, "quota", contentCli.QuotaUsage())
+
+	return err
 }
 
 // main initiates new app from argument receiver over cli args or env and calls serve to start the server
@@ -69,7 +298,7 @@ func main() {
 	}
 
 	var rootCmd = &cobra.Command{Use: "lite-api"}
-	rootCmd.AddCommand(startCmdHandler)
+	rootCmd.AddCommand(startCmdHandler, cli.CreateSyncContentCmdHandler(syncContent, logger))
 	if err := rootCmd.Execute(); err != nil {
 		logger.Error("error starting lite-api application", "err", err)
 		os.Exit(1)
@@ -1,10 +1,20 @@
 package app
 
 import (
+	"crypto/subtle"
+	"errors"
+	"lite-api/internal/client"
+	"lite-api/internal/client/breaker"
+	"lite-api/internal/client/cache"
+	"lite-api/internal/client/hotelbeds"
 	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
 	"lite-api/internal/service"
 	"log/slog"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -14,19 +24,72 @@ import (
 // This can be updated during linking so that it can be used for continuous delivery.
 var ApiVersion = "1.0.0"
 
+// HeaderXCache reports whether a search was served from the availability cache.
+const HeaderXCache = "X-Cache"
+
+// HeaderXSupplierKey reports the supplier account and key names which served a request, e.g. eu/2026-q4.
+const HeaderXSupplierKey = "X-Supplier-Key"
+
+// keyRecorderKey is the gin context key of the client.KeyRecorder of a request.
+const keyRecorderKey = "keyRecorder"
+
+// HeaderRetryAfter tells callers how long to wait before retrying a rate limited or unavailable request.
+const HeaderRetryAfter = "Retry-After"
+
+// bearerPrefix prefixes the admin token in the Authorization header of admin requests.
+const bearerPrefix = "Bearer "
+
+// Circuit is a supplier circuit breaker whose state is reported by the health check.
+type Circuit interface {
+	Name() string
+	State() breaker.State
+}
+
+// Quota is a supplier request quota whose usage is reported by the health check.
+type Quota interface {
+	QuotaUsage() hotelbeds.QuotaUsage
+}
+
+// Health lists the supplier components reported by the health check, the zero value reports none.
+type Health struct {
+	Circuits []Circuit
+	// Quotas maps supplier names to their quota.
+	Quotas map[string]Quota
+}
+
+// SupplierConfigPolicy tells what callers may override with the x-liteapi-supplier-config header, the zero value
+// only lets them pick the supplier and timeout.
+type SupplierConfigPolicy struct {
+	// Environments are the supplier environments callers may send requests to.
+	Environments []string
+	// Accounts are the supplier accounts callers may sign requests with.
+	Accounts []string
+	// ApiKeys are the supplier API keys callers may send credentials of.
+	ApiKeys []string
+}
+
 // Hotel interfaces external HTTP and proxies the requests to Hotelbeds.
 type Hotel struct {
-	hotelService service.HotelService
-	mode         string
-	logger       *slog.Logger
+	hotelService   service.HotelService
+	mode           string
+	logger         *slog.Logger
+	health         Health
+	adminToken     string
+	supplierPolicy SupplierConfigPolicy
 }
 
-// NewHotel returns app configured with passed surveyService.
-func NewHotel(appMode string, hotelService service.HotelService, logger *slog.Logger) *Hotel {
+// NewHotel returns app configured with passed surveyService, the components in health are reported by the health check.
+// The admin endpoints are only exposed when adminToken is set, to callers sending it as a bearer token. The
+// x-liteapi-supplier-config header of requests is refused when it breaks supplierPolicy.
+func NewHotel(appMode string, hotelService service.HotelService, logger *slog.Logger, health Health,
+	adminToken string, supplierPolicy SupplierConfigPolicy) *Hotel {
 	return &Hotel{
-		hotelService: hotelService,
-		logger:       logger,
-		mode:         appMode,
+		hotelService:   hotelService,
+		logger:         logger,
+		mode:           appMode,
+		health:         health,
+		adminToken:     adminToken,
+		supplierPolicy: supplierPolicy,
 	}
 }
 
@@ -38,30 +101,153 @@ func (h *Hotel) RegisterRoutes() http.Handler {
 	}
 
 	router := gin.Default()
+	// Handlers pass the gin context on, it must carry the values of the request context set by middlewares.
+	router.ContextWithFallback = true
 	router.GET("/", h.HealthCheck)
 
 	{
-		hotelsG := router.Group("/hotels")
+		hotelsG := router.Group("/hotels", h.supplierConfig)
 
 		hotelsG.GET("/", h.Search)
+		hotelsG.GET("/:id", h.HotelContent)
+		hotelsG.POST("/rates/check", h.CheckRate)
+	}
+
+	{
+		bookingsG := router.Group("/bookings", h.supplierConfig)
+
+		bookingsG.POST("/", h.Book)
+		bookingsG.GET("/:reference", h.BookingDetail)
+		bookingsG.DELETE("/:reference", h.CancelBooking)
+	}
+
+	if h.adminToken != "" {
+		adminG := router.Group("/admin", h.requireAdmin)
+
+		adminG.GET("/pricing/match", h.MatchPricingRule)
 	}
 
 	return router
 }
 
+// requireAdmin rejects requests without the admin bearer token.
+func (h *Hotel) requireAdmin(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), bearerPrefix)
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		h.logger.Debug("admin request unauthorized", "path", c.Request.URL.Path)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+		return
+	}
+
+	c.Next()
+}
+
+// supplierConfig passes the x-liteapi-supplier-config header of the request on through its context, along with a
+// client.KeyRecorder reported by reportKeys. Malformed headers are rejected, as are environments, accounts and
+// credentials outside of the supplier policy.
+func (h *Hotel) supplierConfig(c *gin.Context) {
+	ctx, keyRecorder := client.WithKeyRecorder(c.Request.Context())
+	c.Set(keyRecorderKey, keyRecorder)
+
+	header := c.GetHeader(dto.HeaderSupplierConfig)
+	if header == "" {
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		return
+	}
+
+	cfgReq, err := dto.ParseSupplierConfig(header)
+	if err != nil {
+		h.logger.Debug("supplier config header parsing failed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := cfgReq.Validate(); err != nil {
+		h.logger.Debug("supplier config header validation failed")
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	if cfgReq.Environment != "" && !slices.Contains(h.supplierPolicy.Environments, cfgReq.Environment) {
+		h.logger.Debug("supplier config environment refused", "environment", cfgReq.Environment)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "environment is not allowed"})
+		return
+	}
+
+	if cfgReq.Account != "" && !slices.Contains(h.supplierPolicy.Accounts, cfgReq.Account) {
+		h.logger.Debug("supplier config account refused", "account", cfgReq.Account)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "account is not allowed"})
+		return
+	}
+
+	if cfgReq.Credentials != nil && !h.allowedApiKey(cfgReq.Credentials.ApiKey) {
+		h.logger.Debug("supplier config credentials refused")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "credentials are not allowed"})
+		return
+	}
+
+	c.Request = c.Request.WithContext(client.WithSupplierConfig(ctx, cfgReq.Transform()))
+	c.Next()
+}
+
+// reportKeys sets the X-Supplier-Key header to the supplier keys which served the request, if any.
+func (h *Hotel) reportKeys(c *gin.Context) {
+	keyRecorder, ok := c.Value(keyRecorderKey).(*client.KeyRecorder)
+	if !ok {
+		return
+	}
+
+	if keys := keyRecorder.Keys(); len(keys) > 0 {
+		c.Header(HeaderXSupplierKey, strings.Join(keys, ","))
+	}
+}
+
+// allowedApiKey reports whether apiKey is one of the API keys of the supplier policy.
+func (h *Hotel) allowedApiKey(apiKey string) bool {
+	for _, allowed := range h.supplierPolicy.ApiKeys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(allowed)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HealthCheckResponse is the response struct which reports app health.
 type HealthCheckResponse struct {
 	Status     string `json:"status"`
 	ApiVersion string `json:"api_version"`
+	// Circuits is the circuit breaker state per supplier.
+	Circuits map[string]breaker.State `json:"circuits,omitempty"`
+	// Quotas is the daily quota usage per supplier.
+	Quotas map[string]hotelbeds.QuotaUsage `json:"quotas,omitempty"`
 }
 
 // HealthCheck reports  app health
+// An open circuit does not fail the health check, the app itself is still able to serve requests.
 func (h *Hotel) HealthCheck(c *gin.Context) {
 	h.logger.Debug("health check request received")
-	c.JSONP(http.StatusOK, HealthCheckResponse{
+	resp := HealthCheckResponse{
 		Status:     http.StatusText(http.StatusOK),
 		ApiVersion: ApiVersion,
-	})
+	}
+
+	if len(h.health.Circuits) > 0 {
+		resp.Circuits = make(map[string]breaker.State, len(h.health.Circuits))
+		for _, circuit := range h.health.Circuits {
+			resp.Circuits[circuit.Name()] = circuit.State()
+		}
+	}
+
+	if len(h.health.Quotas) > 0 {
+		resp.Quotas = make(map[string]hotelbeds.QuotaUsage, len(h.health.Quotas))
+		for name, quota := range h.health.Quotas {
+			resp.Quotas[name] = quota.QuotaUsage()
+		}
+	}
+
+	c.JSONP(http.StatusOK, resp)
 }
 
 func (h *Hotel) Search(c *gin.Context) {
@@ -79,13 +265,222 @@ func (h *Hotel) Search(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.hotelService.Search(c, searchReq)
+	ctx, cacheRecorder := cache.WithRecorder(c.Request.Context())
+	resp, err := h.hotelService.Search(ctx, searchReq)
+	if status := cacheRecorder.Status(); status != "" {
+		c.Header(HeaderXCache, status)
+	}
+
+	h.reportKeys(c)
+
 	if err != nil {
 		h.logger.Debug("search request service failed", "err", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.respondServiceErr(c, err)
 		return
 	}
 
 	c.JSONP(http.StatusOK, resp)
 	h.logger.Debug("search request success", "resp", resp)
 }
+
+// CheckRate re-prices the rate keys in the request body before booking.
+func (h *Hotel) CheckRate(c *gin.Context) {
+	checkRateReq := dto.CheckRateRequest{}
+	if err := c.ShouldBindJSON(&checkRateReq); err != nil {
+		h.logger.Debug("check rate request binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("check rate request received", "body", checkRateReq)
+	if err := checkRateReq.Validate(); err != nil {
+		h.logger.Debug("check rate request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.CheckRate(c, checkRateReq)
+	h.reportKeys(c)
+	if err != nil {
+		h.logger.Debug("check rate request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("check rate request success", "resp", resp)
+}
+
+// Book confirms the booking of the rate keys in the request body.
+func (h *Hotel) Book(c *gin.Context) {
+	bookingReq := dto.BookingRequest{}
+	if err := c.ShouldBindJSON(&bookingReq); err != nil {
+		h.logger.Debug("booking request binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("booking request received", "clientReference", bookingReq.ClientReference)
+	if err := bookingReq.Validate(); err != nil {
+		h.logger.Debug("booking request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.Book(c, bookingReq)
+	h.reportKeys(c)
+	if err != nil {
+		h.logger.Debug("booking request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("booking request success", "reference", resp.Data.Reference)
+}
+
+// BookingDetail returns the booking with the reference in the path.
+func (h *Hotel) BookingDetail(c *gin.Context) {
+	detailReq := dto.BookingDetailRequest{}
+	if err := c.ShouldBindUri(&detailReq); err != nil {
+		h.logger.Debug("booking detail request uri binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&detailReq); err != nil {
+		h.logger.Debug("booking detail request query binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("booking detail request received", "reference", detailReq.Reference)
+	if err := detailReq.Validate(); err != nil {
+		h.logger.Debug("booking detail request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.BookingDetail(c, detailReq)
+	h.reportKeys(c)
+	if err != nil {
+		h.logger.Debug("booking detail request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("booking detail request success", "reference", detailReq.Reference)
+}
+
+// CancelBooking cancels the booking with the reference in the path.
+// Passing mode=simulation only reports the cancellation cost.
+func (h *Hotel) CancelBooking(c *gin.Context) {
+	cancelReq := dto.CancelBookingRequest{}
+	if err := c.ShouldBindUri(&cancelReq); err != nil {
+		h.logger.Debug("cancel booking request uri binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&cancelReq); err != nil {
+		h.logger.Debug("cancel booking request query binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("cancel booking request received", "query", cancelReq)
+	if err := cancelReq.Validate(); err != nil {
+		h.logger.Debug("cancel booking request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.CancelBooking(c, cancelReq)
+	h.reportKeys(c)
+	if err != nil {
+		h.logger.Debug("cancel booking request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("cancel booking request success", "reference", cancelReq.Reference)
+}
+
+// respondServiceErr answers with the status matching a supplier APIErr and a structured body,
+// any other error is answered as an internal server error.
+// A Retry-After sent by the supplier is passed on to the caller.
+func (h *Hotel) respondServiceErr(c *gin.Context, err error) {
+	var apiErr *liteapierrors.APIErr
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter() > 0 {
+			c.Header(HeaderRetryAfter, strconv.Itoa(int(math.Ceil(apiErr.RetryAfter().Seconds()))))
+		}
+		c.JSON(apiErr.HTTPStatus(), dto.NewErrorResponse(apiErr))
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// HotelContent returns the static data of a hotel from the Hotelbeds Content API.
+func (h *Hotel) HotelContent(c *gin.Context) {
+	contentReq := dto.HotelContentRequest{}
+	if err := c.ShouldBindUri(&contentReq); err != nil {
+		h.logger.Debug("hotel content request uri binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&contentReq); err != nil {
+		h.logger.Debug("hotel content request query binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("hotel content request received", "query", contentReq)
+	if err := contentReq.Validate(); err != nil {
+		h.logger.Debug("hotel content request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.HotelContent(c, contentReq)
+	h.reportKeys(c)
+	if err != nil {
+		h.logger.Debug("hotel content request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("hotel content request success", "hotelId", contentReq.HotelID)
+}
+
+// MatchPricingRule shows the pricing rule marking up a net price for the hotel described in the query.
+func (h *Hotel) MatchPricingRule(c *gin.Context) {
+	matchReq := dto.PricingMatchRequest{}
+	if err := c.ShouldBindQuery(&matchReq); err != nil {
+		h.logger.Debug("pricing match request query binding failed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Debug("pricing match request received", "query", matchReq)
+	if err := matchReq.Validate(); err != nil {
+		h.logger.Debug("pricing match request validation failed")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.hotelService.MatchPricingRule(c, matchReq)
+	if err != nil {
+		h.logger.Debug("pricing match request service failed", "err", err)
+		h.respondServiceErr(c, err)
+		return
+	}
+
+	c.JSONP(http.StatusOK, resp)
+	h.logger.Debug("pricing match request success", "resp", resp)
+}
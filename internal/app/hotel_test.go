@@ -2,10 +2,17 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"lite-api/internal/client"
+	"lite-api/internal/client/breaker"
+	"lite-api/internal/client/cache"
+	"lite-api/internal/client/hotelbeds"
+	hotelbedsmock "lite-api/internal/client/mock"
 	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
 	"lite-api/internal/model"
 	"lite-api/internal/service"
 	servicemock "lite-api/internal/service/mock"
@@ -16,12 +23,24 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
 	"go.uber.org/mock/gomock"
 )
 
+// testAdminToken is the admin token of the router returned by setup.
+const testAdminToken = "test-admin-token"
+
+// testSupplierPolicy is the supplier config policy of the router returned by setup.
+var testSupplierPolicy = SupplierConfigPolicy{
+	Environments: []string{"test", "live"},
+	Accounts:     []string{"eu"},
+	ApiKeys:      []string{"allowed-key"},
+}
+
 func setup(tb testing.TB, hotelService service.HotelService, logLevel slog.Level) (http.Handler, *bytes.Buffer) {
 	tb.Helper()
 	buf := &bytes.Buffer{}
@@ -36,7 +55,7 @@ func setup(tb testing.TB, hotelService service.HotelService, logLevel slog.Level
 		},
 	}))
 
-	hotel := NewHotel("test", hotelService, logger)
+	hotel := NewHotel("test", hotelService, logger, Health{}, testAdminToken, testSupplierPolicy)
 	return hotel.RegisterRoutes(), buf
 }
 
@@ -55,7 +74,7 @@ func buildQueryFromSearch(tb testing.TB, currency model.Currency, guestNationali
 		query.WriteString("&")
 	}
 
-	if len(q.Hotels.Hotel) > 0 {
+	if q.Hotels != nil && len(q.Hotels.Hotel) > 0 {
 		hotelIds := make([]string, len(q.Hotels.Hotel))
 		for i, hotelId := range q.Hotels.Hotel {
 			hotelIds[i] = strconv.Itoa(hotelId)
@@ -66,6 +85,22 @@ func buildQueryFromSearch(tb testing.TB, currency model.Currency, guestNationali
 		query.WriteString("&")
 	}
 
+	if q.Destination != nil {
+		query.WriteString("destination=")
+		query.WriteString(q.Destination.Code)
+		query.WriteString("&")
+		if q.Destination.Zone != 0 {
+			query.WriteString("zone=")
+			query.WriteString(strconv.Itoa(q.Destination.Zone))
+			query.WriteString("&")
+		}
+	}
+
+	if q.Geolocation != nil {
+		query.WriteString(fmt.Sprintf("latitude=%g&longitude=%g&radius=%g&unit=%s&",
+			q.Geolocation.Latitude, q.Geolocation.Longitude, q.Geolocation.Radius, q.Geolocation.Unit))
+	}
+
 	if len(q.Occupancies) > 0 {
 		occupancyList, err := json.Marshal(q.Occupancies)
 		require.NoError(tb, err)
@@ -105,7 +140,7 @@ func TestHotel_RegisterRoutes(t *testing.T) {
 			},
 		}))
 		mockHotelService := servicemock.NewMockHotelService(ctrl)
-		hotel := NewHotel("prod", mockHotelService, logger)
+		hotel := NewHotel("prod", mockHotelService, logger, Health{}, "", SupplierConfigPolicy{})
 		_ = hotel.RegisterRoutes()
 		loggedData, err := io.ReadAll(buf)
 		require.NoError(t, err)
@@ -151,6 +186,46 @@ func TestHotel_HealthCheck(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"health check request received\"}\n")
 	})
+
+	t.Run("reports circuit breaker states and quota usage", func(t *testing.T) {
+		logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+		usage := hotelbeds.QuotaUsage{Day: "2024-07-12", Used: 12, Limit: 100}
+		router := NewHotel("test", nil, logger, Health{
+			Circuits: []Circuit{staticCircuit{"hotelbeds", breaker.StateOpen}},
+			Quotas:   map[string]Quota{"hotelbeds": staticQuota(usage)},
+		}, "", SupplierConfigPolicy{}).RegisterRoutes()
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		healthCheckResponse := HealthCheckResponse{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &healthCheckResponse))
+		require.Equal(t, map[string]breaker.State{"hotelbeds": breaker.StateOpen}, healthCheckResponse.Circuits)
+		require.Equal(t, map[string]hotelbeds.QuotaUsage{"hotelbeds": usage}, healthCheckResponse.Quotas)
+	})
+}
+
+// staticQuota is a Quota whose usage never changes.
+type staticQuota hotelbeds.QuotaUsage
+
+func (s staticQuota) QuotaUsage() hotelbeds.QuotaUsage {
+	return hotelbeds.QuotaUsage(s)
+}
+
+// staticCircuit is a Circuit which never changes state.
+type staticCircuit struct {
+	name  string
+	state breaker.State
+}
+
+func (s staticCircuit) Name() string {
+	return s.name
+}
+
+func (s staticCircuit) State() breaker.State {
+	return s.state
 }
 
 func TestHotel_Search(t *testing.T) {
@@ -184,7 +259,7 @@ func TestHotel_Search(t *testing.T) {
 				CheckIn:  "2024-07-15",
 				CheckOut: "2024-07-20",
 			},
-			Hotels: client.HotelIds{
+			Hotels: &client.HotelIds{
 				Hotel: []int{10, 20, 30},
 			},
 			Occupancies: client.Occupancies{
@@ -217,7 +292,7 @@ func TestHotel_Search(t *testing.T) {
 				CheckIn:  "2024-07-15",
 				CheckOut: "2024-07-20",
 			},
-			Hotels: client.HotelIds{
+			Hotels: &client.HotelIds{
 				Hotel: []int{10, 20, 30},
 			},
 			Occupancies: client.Occupancies{
@@ -257,6 +332,104 @@ func TestHotel_Search(t *testing.T) {
 		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"search request service failed\"")
 	})
 
+	t.Run("supplier failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		searchReq := client.SearchRequest{
+			Stay: client.Stay{
+				CheckIn:  "2024-07-15",
+				CheckOut: "2024-07-20",
+			},
+			Hotels: &client.HotelIds{
+				Hotel: []int{10, 20, 30},
+			},
+			Occupancies: client.Occupancies{
+				{
+					Adults:   2,
+					Children: 0,
+					Rooms:    1,
+				},
+			},
+		}
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		query := buildQueryFromSearch(t, "USD", "US", searchReq)
+
+		tests := []struct {
+			name           string
+			err            error
+			wantStatus     int
+			wantRetryAfter string
+			wantBody       dto.ErrorResponse
+		}{
+			{
+				name:       "supplier rejected credentials",
+				err:        liteapierrors.NewUpstreamErr(http.StatusUnauthorized, "Unauthorized", "invalid key"),
+				wantStatus: http.StatusBadGateway,
+				wantBody: dto.ErrorResponse{Error: dto.ErrorDetail{
+					Code:           string(liteapierrors.KindSupplierAuth),
+					Message:        "invalid key",
+					SupplierCode:   "Unauthorized",
+					SupplierStatus: http.StatusUnauthorized,
+				}},
+			},
+			{
+				name: "supplier rate limited",
+				err: liteapierrors.NewUpstreamErr(http.StatusTooManyRequests, "Too Many Requests", "slow down").
+					WithRetryAfter(1500 * time.Millisecond),
+				wantStatus:     http.StatusTooManyRequests,
+				wantRetryAfter: "2",
+				wantBody: dto.ErrorResponse{Error: dto.ErrorDetail{
+					Code:           string(liteapierrors.KindRateLimited),
+					Message:        "slow down",
+					Retryable:      true,
+					SupplierCode:   "Too Many Requests",
+					SupplierStatus: http.StatusTooManyRequests,
+				}},
+			},
+			{
+				name:       "supplier unavailable",
+				err:        liteapierrors.NewUpstreamErr(http.StatusServiceUnavailable, "Service Unavailable", "internal server error"),
+				wantStatus: http.StatusServiceUnavailable,
+				wantBody: dto.ErrorResponse{Error: dto.ErrorDetail{
+					Code:           string(liteapierrors.KindSupplierUnavailable),
+					Message:        "internal server error",
+					Retryable:      true,
+					SupplierCode:   "Service Unavailable",
+					SupplierStatus: http.StatusServiceUnavailable,
+				}},
+			},
+			{
+				name:       "supplier rejected request",
+				err:        fmt.Errorf("wrapped: %w", liteapierrors.NewUpstreamErr(http.StatusBadRequest, "INVALID_DATA", "bad dates")),
+				wantStatus: http.StatusBadRequest,
+				wantBody: dto.ErrorResponse{Error: dto.ErrorDetail{
+					Code:           string(liteapierrors.KindInvalidRequest),
+					Message:        "bad dates",
+					SupplierCode:   "INVALID_DATA",
+					SupplierStatus: http.StatusBadRequest,
+				}},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockHotelService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(dto.SearchResponse{}, tt.err)
+				req, _ := http.NewRequest(http.MethodGet, "/hotels/?"+query, nil)
+				resp := httptest.NewRecorder()
+
+				router.ServeHTTP(resp, req)
+				require.Equal(t, tt.wantStatus, resp.Code)
+				require.Equal(t, tt.wantRetryAfter, resp.Header().Get(HeaderRetryAfter))
+
+				var errResp dto.ErrorResponse
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &errResp))
+				require.Equal(t, tt.wantBody, errResp)
+			})
+		}
+	})
+
 	t.Run("success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -267,7 +440,7 @@ func TestHotel_Search(t *testing.T) {
 				CheckIn:  "2024-07-15",
 				CheckOut: "2024-07-20",
 			},
-			Hotels: client.HotelIds{
+			Hotels: &client.HotelIds{
 				Hotel: []int{10, 20, 30},
 			},
 			Occupancies: client.Occupancies{
@@ -303,4 +476,541 @@ func TestHotel_Search(t *testing.T) {
 		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"search request received\"")
 		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"search request success\"")
 	})
+
+	t.Run("success by destination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		query := buildQueryFromSearch(t, "USD", "US", client.SearchRequest{
+			Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-20"},
+			Destination: &client.Destination{Code: "PMI", Zone: 20},
+			Occupancies: client.Occupancies{{Adults: 2, Rooms: 1}},
+		})
+		vals, err := url.ParseQuery(query)
+		require.NoError(t, err)
+
+		expectedReq := dto.SearchRequest{
+			CheckIn:          "2024-07-15",
+			CheckOut:         "2024-07-20",
+			Occupancies:      model.OccupancyList(vals.Get("occupancies")),
+			Destination:      "PMI",
+			Zone:             20,
+			GuestNationality: "US",
+			Currency:         "USD",
+		}
+		mockHotelService.EXPECT().Search(gomock.Any(), expectedReq).Return(dto.SearchResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/?"+query, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("success by geolocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		query := buildQueryFromSearch(t, "USD", "US", client.SearchRequest{
+			Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-20"},
+			Geolocation: &client.Geolocation{Latitude: 39.57, Longitude: 2.65, Radius: 5, Unit: client.UnitMiles},
+			Occupancies: client.Occupancies{{Adults: 2, Rooms: 1}},
+		})
+		vals, err := url.ParseQuery(query)
+		require.NoError(t, err)
+
+		latitude, longitude, radius := 39.57, 2.65, 5.0
+		expectedReq := dto.SearchRequest{
+			CheckIn:          "2024-07-15",
+			CheckOut:         "2024-07-20",
+			Occupancies:      model.OccupancyList(vals.Get("occupancies")),
+			Latitude:         &latitude,
+			Longitude:        &longitude,
+			Radius:           &radius,
+			Unit:             client.UnitMiles,
+			GuestNationality: "US",
+			Currency:         "USD",
+		}
+		mockHotelService.EXPECT().Search(gomock.Any(), expectedReq).Return(dto.SearchResponse{}, nil)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/?"+query, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	})
+}
+
+func TestHotel_SearchCacheHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHotelService := servicemock.NewMockHotelService(ctrl)
+	cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+	availabilityCache := cache.NewCache(cliMock, time.Minute, 1<<20, clock.New())
+
+	cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, nil).Times(1)
+	mockHotelService.EXPECT().Search(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(ctx context.Context, req dto.SearchRequest) (dto.SearchResponse, error) {
+			searchReq, err := req.Transform()
+			require.NoError(t, err)
+			_, err = availabilityCache.Search(ctx, searchReq)
+			return dto.SearchResponse{}, err
+		})
+
+	router, _ := setup(t, mockHotelService, slog.LevelDebug)
+	query := buildQueryFromSearch(t, "USD", "US", client.SearchRequest{
+		Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-20"},
+		Hotels:      &client.HotelIds{Hotel: []int{10, 20, 30}},
+		Occupancies: client.Occupancies{{Adults: 2, Rooms: 1}},
+	})
+
+	for _, wantStatus := range []string{cache.StatusMiss, cache.StatusHit} {
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/?"+query, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, wantStatus, resp.Header().Get(HeaderXCache))
+	}
+}
+
+func TestHotel_SupplierConfig(t *testing.T) {
+	t.Run("passed through the context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		mockHotelService.EXPECT().BookingDetail(gomock.Any(), dto.BookingDetailRequest{Reference: "102-4256498"}).
+			DoAndReturn(func(ctx context.Context, _ dto.BookingDetailRequest) (dto.BookingResponse, error) {
+				require.Equal(t, client.SupplierConfig{
+					Supplier:    "hotelbeds",
+					Environment: "live",
+					Credentials: &client.Credentials{ApiKey: "allowed-key", Secret: "secret"},
+					Timeout:     3 * time.Second,
+				}, client.SupplierConfigFrom(ctx))
+				return dto.BookingResponse{}, nil
+			})
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498", nil)
+		req.Header.Set(dto.HeaderSupplierConfig, `{"supplier":"hotelbeds","environment":"live",`+
+			`"credentials":{"apiKey":"allowed-key","secret":"secret"},"timeout":"3s"}`)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("keys which served the request are reported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		mockHotelService.EXPECT().BookingDetail(gomock.Any(), dto.BookingDetailRequest{Reference: "102-4256498"}).
+			DoAndReturn(func(ctx context.Context, _ dto.BookingDetailRequest) (dto.BookingResponse, error) {
+				require.Equal(t, "eu", client.SupplierConfigFrom(ctx).Account)
+				client.RecordKey(ctx, "eu", "2026-q4")
+				return dto.BookingResponse{}, nil
+			})
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498", nil)
+		req.Header.Set(dto.HeaderSupplierConfig, `{"account":"eu"}`)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "eu/2026-q4", resp.Header().Get(HeaderXSupplierKey))
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+	}{
+		{"malformed", `hotelbeds`, http.StatusBadRequest},
+		{"invalid timeout", `{"timeout":"forever"}`, http.StatusUnprocessableEntity},
+		{"environment not allowed", `{"environment":"staging"}`, http.StatusForbidden},
+		{"account not allowed", `{"account":"us"}`, http.StatusForbidden},
+		{"credentials not allowed", `{"credentials":{"apiKey":"other-key","secret":"secret"}}`, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+			router, _ := setup(t, mockHotelService, slog.LevelDebug)
+			req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498", nil)
+			req.Header.Set(dto.HeaderSupplierConfig, tt.header)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestHotel_CheckRate(t *testing.T) {
+	t.Run("invalid body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/hotels/rates/check", strings.NewReader(`{`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"check rate request binding failed\"}\n")
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/hotels/rates/check", strings.NewReader(`{"rateKeys":[""]}`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"check rate request validation failed\"}\n")
+	})
+
+	t.Run("service failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		expectedReq := dto.CheckRateRequest{RateKeys: []string{"some-rate-key"}}
+		mockHotelService.EXPECT().CheckRate(gomock.Any(), expectedReq).
+			Return(dto.CheckRateResponse{}, liteapierrors.NewUpstreamErr(http.StatusBadRequest, "INVALID_DATA", "rate key expired"))
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/hotels/rates/check", strings.NewReader(`{"rateKeys":["some-rate-key"]}`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Contains(t, resp.Body.String(), "rate key expired")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		expectedReq := dto.CheckRateRequest{RateKeys: []string{"some-rate-key"}}
+		expectedResp := dto.CheckRateResponse{
+			Data: dto.CheckRateInfo{
+				HotelID:  "264",
+				Currency: "EUR",
+				NetPrice: 384.25,
+				Rates: dto.CheckedRates{
+					{RateKey: "some-rate-key", NetPrice: 384.25, CancellationPolicies: dto.CancellationPolicies{}},
+				},
+			},
+		}
+		mockHotelService.EXPECT().CheckRate(gomock.Any(), expectedReq).Return(expectedResp, nil)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/hotels/rates/check", strings.NewReader(`{"rateKeys":["some-rate-key"]}`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var gotResp dto.CheckRateResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gotResp))
+		require.Equal(t, expectedResp.Data, gotResp.Data)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"check rate request success\"")
+	})
+}
+
+func TestHotel_Bookings(t *testing.T) {
+	bookingBody := `{
+		"holder": {"firstName": "Jane", "lastName": "Doe"},
+		"rooms": [{"rateKey": "some-rate-key", "guests": [{"type": "adult", "firstName": "Jane", "lastName": "Doe"}]}],
+		"clientReference": "LITEAPI-0001"
+	}`
+
+	t.Run("book validation failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/bookings/", strings.NewReader(`{"holder": {}, "rooms": [], "clientReference": "x"}`))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"booking request validation failed\"}\n")
+	})
+
+	t.Run("book success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		expectedResp := dto.BookingResponse{Data: dto.BookingInfo{Reference: "102-4256498", Status: "CONFIRMED"}}
+		mockHotelService.EXPECT().Book(gomock.Any(), gomock.Any()).Return(expectedResp, nil)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodPost, "/bookings/", strings.NewReader(bookingBody))
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var gotResp dto.BookingResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gotResp))
+		require.Equal(t, expectedResp.Data, gotResp.Data)
+	})
+
+	t.Run("booking detail not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		mockHotelService.EXPECT().BookingDetail(gomock.Any(), dto.BookingDetailRequest{Reference: "102-4256498"}).
+			Return(dto.BookingResponse{}, liteapierrors.NewUpstreamErr(http.StatusNotFound, "PRODUCT_ERROR", "booking not found"))
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("booking detail with the guest nationality", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		expectedReq := dto.BookingDetailRequest{Reference: "102-4256498", GuestNationality: "ES"}
+		mockHotelService.EXPECT().BookingDetail(gomock.Any(), expectedReq).Return(dto.BookingResponse{}, nil)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498?guestNationality=ES", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("booking detail invalid guest nationality", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/bookings/102-4256498?guestNationality=FR", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("cancel booking invalid mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodDelete, "/bookings/102-4256498?mode=maybe", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("cancel booking simulation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		expectedReq := dto.CancelBookingRequest{Reference: "102-4256498", Mode: dto.CancelModeSimulation}
+		expectedResp := dto.BookingResponse{Data: dto.BookingInfo{Reference: "102-4256498", CancellationAmount: 384.25}}
+		mockHotelService.EXPECT().CancelBooking(gomock.Any(), expectedReq).Return(expectedResp, nil)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodDelete, "/bookings/102-4256498?mode=simulation", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var gotResp dto.BookingResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gotResp))
+		require.Equal(t, expectedResp.Data, gotResp.Data)
+	})
+}
+
+func TestHotel_HotelContent(t *testing.T) {
+	t.Run("invalid hotel id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/abc", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/1067?language=english", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"hotel content request validation failed\"}\n")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+		mockHotelService.EXPECT().HotelContent(gomock.Any(), dto.HotelContentRequest{HotelID: 1067}).
+			Return(dto.HotelContentResponse{}, liteapierrors.NewNotFoundErr("hotel_not_found", "hotel 1067 not found"))
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/1067", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &errResp))
+		require.Equal(t, string(liteapierrors.KindNotFound), errResp.Error.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+		expectedResp := dto.HotelContentResponse{Data: dto.HotelContent{HotelID: "1067", Name: "Hotel Bellevue", StarRating: 3}}
+		mockHotelService.EXPECT().HotelContent(gomock.Any(), dto.HotelContentRequest{HotelID: 1067, Language: "CAS"}).
+			Return(expectedResp, nil)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/hotels/1067?language=CAS", nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var gotResp dto.HotelContentResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gotResp))
+		require.Equal(t, expectedResp, gotResp)
+	})
+}
+
+func TestHotel_MatchPricingRule(t *testing.T) {
+	t.Run("admin routes disabled without token", func(t *testing.T) {
+		logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+		router := NewHotel("test", nil, logger, Health{}, "", SupplierConfigPolicy{}).RegisterRoutes()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/pricing/match?netPrice=100", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		for _, authorization := range []string{"", testAdminToken, "Bearer wrong-token"} {
+			req, _ := http.NewRequest(http.MethodGet, "/admin/pricing/match?netPrice=100", nil)
+			req.Header.Set("Authorization", authorization)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+			require.Equal(t, http.StatusUnauthorized, resp.Code)
+		}
+	})
+
+	t.Run("validation failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+
+		router, buf := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet, "/admin/pricing/match?netPrice=100&stars=7", nil)
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+
+		loggedData, err := io.ReadAll(buf)
+		require.NoError(t, err)
+		require.Contains(t, string(loggedData), "{\"level\":\"DEBUG\",\"msg\":\"pricing match request validation failed\"}\n")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockHotelService := servicemock.NewMockHotelService(ctrl)
+		expectedResp := dto.PricingMatchResponse{Data: dto.PricingMatch{
+			NetPrice:     100,
+			SellingPrice: 110,
+			Rule:         &dto.PricingRule{Name: "mallorca", Priority: 10, MarkupType: "percentage", MarkupValue: 10},
+		}}
+		mockHotelService.EXPECT().MatchPricingRule(gomock.Any(), dto.PricingMatchRequest{
+			Destination: "PMI",
+			Stars:       4,
+			Channel:     "web",
+			CheckIn:     "2024-07-15",
+			NetPrice:    100,
+		}).Return(expectedResp, nil)
+
+		router, _ := setup(t, mockHotelService, slog.LevelDebug)
+		req, _ := http.NewRequest(http.MethodGet,
+			"/admin/pricing/match?destination=PMI&stars=4&channel=web&checkin=2024-07-15&netPrice=100", nil)
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var gotResp dto.PricingMatchResponse
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &gotResp))
+		require.Equal(t, expectedResp, gotResp)
+	})
 }
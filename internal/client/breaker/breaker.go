@@ -0,0 +1,249 @@
+// Package breaker stops sending requests to Hotelbeds while it is failing, so that callers fail fast
+// instead of waiting for timeouts during an outage.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"lite-api/internal/client"
+	liteapierrors "lite-api/internal/errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"go.nhat.io/clock"
+)
+
+// State is the state of a Breaker.
+type State string
+
+const (
+	// StateClosed lets every request through.
+	StateClosed State = "closed"
+	// StateOpen rejects every request until the cool-down has elapsed.
+	StateOpen State = "open"
+	// StateHalfOpen lets a single probe request through, its outcome closes or re-opens the circuit.
+	StateHalfOpen State = "half-open"
+)
+
+// Config configures when a Breaker opens and for how long.
+type Config struct {
+	// Window is the number of most recent requests the failure rate is computed over.
+	Window int
+	// MinRequests is the number of requests in the window below which the circuit never opens.
+	MinRequests int
+	// FailureRate is the fraction of failed requests in the window, between 0 and 1, which opens the circuit.
+	FailureRate float64
+	// CoolDown is how long the circuit stays open before a probe request is let through.
+	CoolDown time.Duration
+	// Unguarded are the environments a client.SupplierConfig may pick which send requests to another host than
+	// the guarded one.
+	Unguarded []string
+}
+
+// Breaker is a client.HotelBeds decorator implementing a circuit breaker.
+// Only outages count as failures: unavailable responses, timeouts and transport errors.
+// Errors caused by the request itself, like invalid data, unknown bookings or the expiry of a timeout it set with
+// its client.SupplierConfig, leave the circuit closed. Requests to the Unguarded environments are not guarded, the
+// outages of another host say nothing about the guarded one.
+type Breaker struct {
+	next   client.HotelBeds
+	name   string
+	cfg    Config
+	clock  clock.Clock
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	state    State
+	outcomes []bool
+	pos      int
+	count    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker returns Breaker named after the supplier it guards, wrapping next.
+func NewBreaker(next client.HotelBeds, name string, cfg Config, clock clock.Clock, logger *slog.Logger) *Breaker {
+	return &Breaker{
+		next:     next,
+		name:     name,
+		cfg:      cfg,
+		clock:    clock,
+		logger:   logger,
+		state:    StateClosed,
+		outcomes: make([]bool, max(cfg.Window, 1)),
+	}
+}
+
+// Name returns the name of the supplier guarded by the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.clock.Now().Sub(b.openedAt) >= b.cfg.CoolDown {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
+
+func (b *Breaker) Search(ctx context.Context, searchReq client.SearchRequest) (client.SearchResponse, error) {
+	return guard(ctx, b, func() (client.SearchResponse, error) {
+		return b.next.Search(ctx, searchReq)
+	})
+}
+
+func (b *Breaker) CheckRate(ctx context.Context, checkRateReq client.CheckRateRequest) (client.CheckRateResponse, error) {
+	return guard(ctx, b, func() (client.CheckRateResponse, error) {
+		return b.next.CheckRate(ctx, checkRateReq)
+	})
+}
+
+func (b *Breaker) Book(ctx context.Context, bookingReq client.BookingRequest) (client.BookingResponse, error) {
+	return guard(ctx, b, func() (client.BookingResponse, error) {
+		return b.next.Book(ctx, bookingReq)
+	})
+}
+
+func (b *Breaker) BookingDetail(ctx context.Context, reference string) (client.BookingResponse, error) {
+	return guard(ctx, b, func() (client.BookingResponse, error) {
+		return b.next.BookingDetail(ctx, reference)
+	})
+}
+
+func (b *Breaker) CancelBooking(ctx context.Context, reference string, flag client.CancellationFlag) (client.BookingResponse, error) {
+	return guard(ctx, b, func() (client.BookingResponse, error) {
+		return b.next.CancelBooking(ctx, reference, flag)
+	})
+}
+
+// guard calls fn if the circuit lets the request of ctx through and records its outcome.
+func guard[T any](ctx context.Context, b *Breaker, fn func() (T, error)) (T, error) {
+	cfg := client.SupplierConfigFrom(ctx)
+	if cfg.Environment != "" && slices.Contains(b.cfg.Unguarded, cfg.Environment) {
+		return fn()
+	}
+
+	if err := b.allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	res, err := fn()
+	outcome := err
+	if cfg.Timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+		// The caller chose the timeout, its expiry says nothing about the supplier.
+		outcome = context.Canceled
+	}
+	b.record(outcome)
+
+	return res, err
+}
+
+// allow returns a circuit open error if the request must not be sent.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		elapsed := b.clock.Now().Sub(b.openedAt)
+		if elapsed < b.cfg.CoolDown {
+			return liteapierrors.NewCircuitOpenErr(b.name, b.cfg.CoolDown-elapsed)
+		}
+
+		b.transition(StateHalfOpen)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			return liteapierrors.NewCircuitOpenErr(b.name, 0)
+		}
+
+		b.probing = true
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request which was let through.
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		// The caller went away, this says nothing about the supplier.
+		b.probing = false
+		return
+	}
+
+	failed := isFailure(err)
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+			return
+		}
+
+		b.reset()
+		b.transition(StateClosed)
+		return
+	}
+
+	if b.count == len(b.outcomes) && b.outcomes[b.pos] {
+		b.failures--
+	}
+
+	b.outcomes[b.pos] = failed
+	b.pos = (b.pos + 1) % len(b.outcomes)
+	b.count = min(b.count+1, len(b.outcomes))
+	if failed {
+		b.failures++
+	}
+
+	if b.state == StateClosed && b.count >= b.cfg.MinRequests &&
+		float64(b.failures)/float64(b.count) >= b.cfg.FailureRate {
+		b.open()
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.clock.Now()
+	b.reset()
+	b.transition(StateOpen)
+}
+
+func (b *Breaker) reset() {
+	clear(b.outcomes)
+	b.pos, b.count, b.failures = 0, 0, 0
+}
+
+func (b *Breaker) transition(state State) {
+	if b.state == state {
+		return
+	}
+
+	b.logger.Info("circuit breaker state changed", "supplier", b.name, "from", b.state, "to", state)
+	b.state = state
+}
+
+// isFailure reports whether err is a sign of the supplier being down.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *liteapierrors.APIErr
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind() == liteapierrors.KindSupplierUnavailable
+	}
+
+	return true
+}
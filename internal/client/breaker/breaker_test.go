@@ -0,0 +1,247 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	liteapierrors "lite-api/internal/errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// manualClock is a clock.Clock which only moves when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+func (m *manualClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+var (
+	errUnavailable = liteapierrors.NewUpstreamErr(http.StatusServiceUnavailable, "Service Unavailable", "internal server error")
+	errInvalid     = liteapierrors.NewUpstreamErr(http.StatusBadRequest, "INVALID_DATA", "bad dates")
+)
+
+var testConfig = Config{
+	Window:      4,
+	MinRequests: 4,
+	FailureRate: 0.5,
+	CoolDown:    10 * time.Second,
+	Unguarded:   []string{"test"},
+}
+
+func newTestBreaker(t *testing.T) (*Breaker, *hotelbedsmock.MockHotelBeds, *manualClock) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	next := hotelbedsmock.NewMockHotelBeds(ctrl)
+	clk := &manualClock{now: time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewBreaker(next, "hotelbeds", testConfig, clk, logger), next, clk
+}
+
+// search sends a search through b answered by next with err.
+func search(b *Breaker, next *hotelbedsmock.MockHotelBeds, err error) error {
+	next.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, err)
+	_, err = b.Search(context.Background(), client.SearchRequest{})
+	return err
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("stays closed below min requests", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		for range testConfig.MinRequests - 1 {
+			require.ErrorIs(t, search(b, next, errUnavailable), errUnavailable)
+		}
+
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("stays closed below failure rate", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		require.NoError(t, search(b, next, nil))
+		require.NoError(t, search(b, next, nil))
+		require.NoError(t, search(b, next, nil))
+		require.Error(t, search(b, next, errUnavailable))
+
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("request errors are not failures", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		for range testConfig.Window {
+			require.ErrorIs(t, search(b, next, errInvalid), errInvalid)
+		}
+
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("old outcomes leave the window", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		require.Error(t, search(b, next, errUnavailable))
+		for range testConfig.Window {
+			require.NoError(t, search(b, next, nil))
+		}
+		require.Error(t, search(b, next, errUnavailable))
+
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("opens and fails fast", func(t *testing.T) {
+		b, next, clk := newTestBreaker(t)
+		require.NoError(t, search(b, next, nil))
+		require.NoError(t, search(b, next, nil))
+		require.Error(t, search(b, next, errUnavailable))
+		require.Error(t, search(b, next, errors.New("connection refused")))
+		require.Equal(t, StateOpen, b.State())
+
+		clk.Advance(4 * time.Second)
+		_, err := b.Search(context.Background(), client.SearchRequest{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindCircuitOpen, apiErr.Kind())
+		require.Equal(t, 6*time.Second, apiErr.RetryAfter())
+
+		_, err = b.Book(context.Background(), client.BookingRequest{})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindCircuitOpen, apiErr.Kind())
+	})
+
+	t.Run("half open probe success closes", func(t *testing.T) {
+		b, next, clk := newTestBreaker(t)
+		for range testConfig.MinRequests {
+			require.Error(t, search(b, next, errUnavailable))
+		}
+		require.Equal(t, StateOpen, b.State())
+
+		clk.Advance(testConfig.CoolDown)
+		require.Equal(t, StateHalfOpen, b.State())
+		require.NoError(t, search(b, next, nil))
+		require.Equal(t, StateClosed, b.State())
+
+		require.Error(t, search(b, next, errUnavailable))
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("half open probe failure reopens", func(t *testing.T) {
+		b, next, clk := newTestBreaker(t)
+		for range testConfig.MinRequests {
+			require.Error(t, search(b, next, errUnavailable))
+		}
+
+		clk.Advance(testConfig.CoolDown)
+		require.ErrorIs(t, search(b, next, errUnavailable), errUnavailable)
+		require.Equal(t, StateOpen, b.State())
+
+		_, err := b.Search(context.Background(), client.SearchRequest{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, testConfig.CoolDown, apiErr.RetryAfter())
+	})
+
+	t.Run("half open lets a single probe through", func(t *testing.T) {
+		b, next, clk := newTestBreaker(t)
+		for range testConfig.MinRequests {
+			require.Error(t, search(b, next, errUnavailable))
+		}
+		clk.Advance(testConfig.CoolDown)
+
+		probing := make(chan struct{})
+		release := make(chan struct{})
+		next.EXPECT().CheckRate(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, client.CheckRateRequest) (client.CheckRateResponse, error) {
+				close(probing)
+				<-release
+				return client.CheckRateResponse{}, nil
+			})
+
+		done := make(chan error)
+		go func() {
+			_, err := b.CheckRate(context.Background(), client.CheckRateRequest{})
+			done <- err
+		}()
+
+		<-probing
+		_, err := b.BookingDetail(context.Background(), "1-1")
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindCircuitOpen, apiErr.Kind())
+
+		close(release)
+		require.NoError(t, <-done)
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("cancelled probe releases half open", func(t *testing.T) {
+		b, next, clk := newTestBreaker(t)
+		for range testConfig.MinRequests {
+			require.Error(t, search(b, next, errUnavailable))
+		}
+		clk.Advance(testConfig.CoolDown)
+
+		next.EXPECT().CancelBooking(gomock.Any(), "1-1", client.CancellationSimulation).
+			Return(client.BookingResponse{}, context.Canceled)
+		_, err := b.CancelBooking(context.Background(), "1-1", client.CancellationSimulation)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, StateHalfOpen, b.State())
+
+		require.NoError(t, search(b, next, nil))
+		require.Equal(t, StateClosed, b.State())
+	})
+	t.Run("caller timeouts are not failures", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Timeout: time.Second})
+		for range testConfig.Window {
+			next.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, context.DeadlineExceeded)
+			_, err := b.Search(ctx, client.SearchRequest{})
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+
+		require.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("environments of the guarded host are guarded", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "live"})
+		for range testConfig.Window {
+			next.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, errUnavailable)
+			_, err := b.Search(ctx, client.SearchRequest{})
+			require.ErrorIs(t, err, errUnavailable)
+		}
+
+		require.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("environments of other hosts are not guarded", func(t *testing.T) {
+		b, next, _ := newTestBreaker(t)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "test"})
+		for range testConfig.Window {
+			next.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, errUnavailable)
+			_, err := b.Search(ctx, client.SearchRequest{})
+			require.ErrorIs(t, err, errUnavailable)
+		}
+
+		require.Equal(t, StateClosed, b.State())
+	})
+}
@@ -0,0 +1,191 @@
+// Package cache provides an in-memory availability cache in front of client.HotelBeds.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"lite-api/internal/client"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.nhat.io/clock"
+)
+
+const (
+	// StatusHit means every availability request of a search was served from the cache.
+	StatusHit = "HIT"
+	// StatusMiss means every availability request of a search was sent to Hotelbeds.
+	StatusMiss = "MISS"
+	// StatusPartial means some availability requests of a search were served from the cache.
+	StatusPartial = "PARTIAL"
+)
+
+// Cache is a client.HotelBeds decorator which serves identical availability searches from memory.
+// Entries expire after ttl and the least recently used ones are evicted once the cached responses
+// exceed maxBytes. Every other call is passed through to the wrapped client.
+type Cache struct {
+	client.HotelBeds
+	clock    clock.Clock
+	ttl      time.Duration
+	maxBytes int
+
+	mu      sync.Mutex
+	size    int
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type entry struct {
+	key string
+	res client.SearchResponse
+	// keys are the supplier keys which served res, reported again to the callers it is served to.
+	keys      []string
+	size      int
+	expiresAt time.Time
+}
+
+// NewCache returns Cache wrapping next.
+func NewCache(next client.HotelBeds, ttl time.Duration, maxBytes int, clock clock.Clock) *Cache {
+	return &Cache{
+		HotelBeds: next,
+		clock:     clock,
+		ttl:       ttl,
+		maxBytes:  maxBytes,
+		lru:       list.New(),
+		entries:   make(map[string]*list.Element),
+	}
+}
+
+// Search returns the cached response of an identical search if it has not expired yet,
+// otherwise it searches the wrapped client and caches the successful response.
+// Cached responses are shared between callers and must not be modified.
+func (c *Cache) Search(ctx context.Context, searchReq client.SearchRequest) (client.SearchResponse, error) {
+	key, err := client.SearchKey(ctx, searchReq)
+	if err != nil {
+		return c.HotelBeds.Search(ctx, searchReq)
+	}
+
+	if e, ok := c.get(key); ok {
+		record(ctx, true)
+		client.RecordKeys(ctx, e.keys)
+		return e.res, nil
+	}
+
+	record(ctx, false)
+	searchCtx, keys := client.WithKeyRecorder(ctx)
+	res, err := c.HotelBeds.Search(searchCtx, searchReq)
+	client.RecordKeys(ctx, keys.Keys())
+	if err != nil {
+		return client.SearchResponse{}, err
+	}
+
+	c.set(key, res, keys.Keys())
+	return res, nil
+}
+
+// Len returns the number of cached responses.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache) get(key string) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if !c.clock.Now().Before(e.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return e, true
+}
+
+func (c *Cache) set(key string, res client.SearchResponse, keys []string) {
+	// The encoded size is used as an estimate of the memory held by the response.
+	payload, err := json.Marshal(res)
+	if err != nil || len(payload) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	e := &entry{
+		key:       key,
+		res:       res,
+		keys:      keys,
+		size:      len(payload),
+		expiresAt: c.clock.Now().Add(c.ttl),
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.size += e.size
+
+	for c.size > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	e := c.lru.Remove(elem).(*entry)
+	delete(c.entries, e.key)
+	c.size -= e.size
+}
+
+type recorderKey struct{}
+
+// Recorder collects the cache hits and misses of the searches made with its context.
+type Recorder struct {
+	hits   atomic.Int32
+	misses atomic.Int32
+}
+
+// WithRecorder returns a copy of ctx carrying a new Recorder.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	rec := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// Status returns StatusHit, StatusMiss or StatusPartial, or an empty string if the cache was not used.
+func (r *Recorder) Status() string {
+	hits, misses := r.hits.Load(), r.misses.Load()
+	switch {
+	case hits == 0 && misses == 0:
+		return ""
+	case misses == 0:
+		return StatusHit
+	case hits == 0:
+		return StatusMiss
+	default:
+		return StatusPartial
+	}
+}
+
+func record(ctx context.Context, hit bool) {
+	rec, ok := ctx.Value(recorderKey{}).(*Recorder)
+	if !ok {
+		return
+	}
+
+	if hit {
+		rec.hits.Add(1)
+		return
+	}
+
+	rec.misses.Add(1)
+}
@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// manualClock is a clock.Clock which only moves when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+func (m *manualClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func searchRequest(hotelIds ...int) client.SearchRequest {
+	return client.SearchRequest{
+		Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-16"},
+		Occupancies: client.Occupancies{{Rooms: 1, Adults: 2}},
+		Hotels:      &client.HotelIds{Hotel: hotelIds},
+	}
+}
+
+func searchResponse(hotelIds ...int) client.SearchResponse {
+	res := client.SearchResponse{}
+	for _, id := range hotelIds {
+		res.Hotels.Hotels = append(res.Hotels.Hotels, client.Hotel{Code: id, MinRate: "100.00", Currency: "EUR"})
+	}
+
+	return res
+}
+
+func TestCache_Search(t *testing.T) {
+	t.Run("serves identical search from cache until ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		clk := &manualClock{now: time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)}
+		cache := NewCache(cliMock, time.Minute, 1<<20, clk)
+
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1, 2)).Return(searchResponse(1, 2), nil).Times(2)
+
+		ctx, rec := WithRecorder(context.Background())
+		res, err := cache.Search(ctx, searchRequest(1, 2))
+		require.NoError(t, err)
+		require.Equal(t, searchResponse(1, 2), res)
+		require.Equal(t, StatusMiss, rec.Status())
+
+		ctx, rec = WithRecorder(context.Background())
+		res, err = cache.Search(ctx, searchRequest(1, 2))
+		require.NoError(t, err)
+		require.Equal(t, searchResponse(1, 2), res)
+		require.Equal(t, StatusHit, rec.Status())
+
+		clk.Advance(time.Minute)
+		ctx, rec = WithRecorder(context.Background())
+		_, err = cache.Search(ctx, searchRequest(1, 2))
+		require.NoError(t, err)
+		require.Equal(t, StatusMiss, rec.Status())
+	})
+
+	t.Run("canonical key ignores ordering", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		clk := &manualClock{}
+		cache := NewCache(cliMock, time.Minute, 1<<20, clk)
+
+		first := searchRequest(1, 2)
+		first.Occupancies = client.Occupancies{
+			{Rooms: 1, Adults: 2, Children: 2, Paxes: client.Paxes{{Type: client.PaxChild, Age: 3}, {Type: client.PaxChild, Age: 9}}},
+			{Rooms: 1, Adults: 1},
+		}
+		second := searchRequest(2, 1)
+		second.Occupancies = client.Occupancies{
+			{Rooms: 1, Adults: 1},
+			{Rooms: 1, Adults: 2, Children: 2, Paxes: client.Paxes{{Type: client.PaxChild, Age: 9}, {Type: client.PaxChild, Age: 3}}},
+		}
+
+		cliMock.EXPECT().Search(gomock.Any(), first).Return(searchResponse(1, 2), nil).Times(1)
+
+		_, err := cache.Search(context.Background(), first)
+		require.NoError(t, err)
+		res, err := cache.Search(context.Background(), second)
+		require.NoError(t, err)
+		require.Equal(t, searchResponse(1, 2), res)
+	})
+
+	t.Run("different searches do not share entries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		other := searchRequest(1, 2)
+		other.SourceMarket = "ES"
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1, 2)).Return(searchResponse(1, 2), nil)
+		cliMock.EXPECT().Search(gomock.Any(), other).Return(searchResponse(1), nil)
+
+		_, err := cache.Search(context.Background(), searchRequest(1, 2))
+		require.NoError(t, err)
+		res, err := cache.Search(context.Background(), other)
+		require.NoError(t, err)
+		require.Equal(t, searchResponse(1), res)
+		require.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("other supplier environments and accounts do not share entries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		live := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "live"})
+		account := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Credentials: &client.Credentials{ApiKey: "key", Secret: "secret"},
+		})
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).Return(searchResponse(1), nil).Times(3)
+
+		for _, ctx := range []context.Context{context.Background(), live, account, live} {
+			_, err := cache.Search(ctx, searchRequest(1))
+			require.NoError(t, err)
+		}
+		require.Equal(t, 3, cache.Len())
+	})
+
+	t.Run("credentials with the same api key and other secrets do not share entries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		owner := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Credentials: &client.Credentials{ApiKey: "key", Secret: "secret"},
+		})
+		impostor := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Credentials: &client.Credentials{ApiKey: "key", Secret: "wrong"},
+		})
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).Return(searchResponse(1), nil)
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).Return(client.SearchResponse{}, assert.AnError)
+
+		_, err := cache.Search(owner, searchRequest(1))
+		require.NoError(t, err)
+		_, err = cache.Search(impostor, searchRequest(1))
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("keys which served the cached response are reported on hits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).
+			DoAndReturn(func(ctx context.Context, _ client.SearchRequest) (client.SearchResponse, error) {
+				client.RecordKey(ctx, "eu", "2026-q4")
+				return searchResponse(1), nil
+			})
+
+		for range 2 {
+			ctx, keys := client.WithKeyRecorder(context.Background())
+			_, err := cache.Search(ctx, searchRequest(1))
+			require.NoError(t, err)
+			require.Equal(t, []string{"eu/2026-q4"}, keys.Keys())
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Return(client.SearchResponse{}, assert.AnError).Times(2)
+
+		for range 2 {
+			_, err := cache.Search(context.Background(), searchRequest(1))
+			require.ErrorIs(t, err, assert.AnError)
+		}
+
+		require.Zero(t, cache.Len())
+	})
+
+	t.Run("evicts least recently used when over memory bound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		entrySize := len(mustMarshal(t, searchResponse(1)))
+		cache := NewCache(cliMock, time.Minute, 2*entrySize, &manualClock{})
+
+		for _, id := range []int{1, 2} {
+			cliMock.EXPECT().Search(gomock.Any(), searchRequest(id)).Return(searchResponse(id), nil)
+			_, err := cache.Search(context.Background(), searchRequest(id))
+			require.NoError(t, err)
+		}
+
+		// Touch 1 so that 2 becomes the least recently used entry.
+		_, err := cache.Search(context.Background(), searchRequest(1))
+		require.NoError(t, err)
+
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(3)).Return(searchResponse(3), nil)
+		_, err = cache.Search(context.Background(), searchRequest(3))
+		require.NoError(t, err)
+		require.Equal(t, 2, cache.Len())
+
+		ctx, rec := WithRecorder(context.Background())
+		_, err = cache.Search(ctx, searchRequest(1))
+		require.NoError(t, err)
+		require.Equal(t, StatusHit, rec.Status())
+
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(2)).Return(searchResponse(2), nil)
+		ctx, rec = WithRecorder(context.Background())
+		_, err = cache.Search(ctx, searchRequest(2))
+		require.NoError(t, err)
+		require.Equal(t, StatusMiss, rec.Status())
+	})
+
+	t.Run("other calls pass through", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cache := NewCache(cliMock, time.Minute, 1<<20, &manualClock{})
+
+		cliMock.EXPECT().BookingDetail(gomock.Any(), "102-4256498").Return(client.BookingResponse{}, nil).Times(2)
+		for range 2 {
+			_, err := cache.BookingDetail(context.Background(), "102-4256498")
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestRecorder_Status(t *testing.T) {
+	ctx, rec := WithRecorder(context.Background())
+	require.Empty(t, rec.Status())
+
+	record(ctx, true)
+	require.Equal(t, StatusHit, rec.Status())
+
+	record(ctx, false)
+	require.Equal(t, StatusPartial, rec.Status())
+
+	// Recording without a recorder in context is a no-op.
+	record(context.Background(), true)
+}
+
+func mustMarshal(tb testing.TB, v any) []byte {
+	tb.Helper()
+	payload, err := json.Marshal(v)
+	require.NoError(tb, err)
+	return payload
+}
@@ -3,23 +3,75 @@ package client
 //go:generate mockgen -source=client.go -destination=./mock/mock.go -package=hotelbedsmock
 
 import (
+	"cmp"
 	"context"
-	"lite-api/internal/model"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
 	"time"
 )
 
-type (
-	// Occupancy is an alias for model.Occupancy.
-	Occupancy = model.Occupancy
-	// Occupancies is a collection of Occupancy.
-	Occupancies = model.Occupancies
-)
+// Occupancy represents the requested occupancy details in Hotelbeds request.
+type Occupancy struct {
+	Rooms    int `json:"rooms"`
+	Adults   int `json:"adults"`
+	Children int `json:"children"`
+	// Paxes carries the age of each child, Hotelbeds does not price children without it.
+	Paxes Paxes `json:"paxes,omitempty"`
+}
+
+// Occupancies is a collection of Occupancy.
+type Occupancies []Occupancy
 
 // SearchRequest is the request format which Hotelbeds expect request in.
 type SearchRequest struct {
 	Stay        Stay        `json:"stay"`
 	Occupancies Occupancies `json:"occupancies"`
-	Hotels      HotelIds    `json:"hotels"`
+	// Hotels, Destination and Geolocation are the scope of the search, exactly one of them is set.
+	Hotels      *HotelIds    `json:"hotels,omitempty"`
+	Destination *Destination `json:"destination,omitempty"`
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+	Filter      *Filter      `json:"filter,omitempty"`
+	Boards      *Boards      `json:"boards,omitempty"`
+	// SourceMarket is the ISO 3166-1 alpha-2 country of the guest, Hotelbeds prices depend on it.
+	SourceMarket string `json:"sourceMarket,omitempty"`
+}
+
+// Key returns a canonical identifier of the search, equal for requests which only differ in the order of
+// hotel ids, occupancies or children ages.
+func (s SearchRequest) Key() (string, error) {
+	normalized := s
+	if s.Hotels != nil {
+		normalized.Hotels = &HotelIds{Hotel: slices.Clone(s.Hotels.Hotel)}
+		slices.Sort(normalized.Hotels.Hotel)
+	}
+
+	normalized.Occupancies = make(Occupancies, len(s.Occupancies))
+	for i, occupancy := range s.Occupancies {
+		occupancy.Paxes = slices.Clone(occupancy.Paxes)
+		slices.SortFunc(occupancy.Paxes, func(a, b Pax) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Age, b.Age))
+		})
+		normalized.Occupancies[i] = occupancy
+	}
+
+	slices.SortFunc(normalized.Occupancies, func(a, b Occupancy) int {
+		return cmp.Or(
+			cmp.Compare(a.Rooms, b.Rooms),
+			cmp.Compare(a.Adults, b.Adults),
+			cmp.Compare(a.Children, b.Children),
+			slices.CompareFunc(a.Paxes, b.Paxes, func(x, y Pax) int { return cmp.Compare(x.Age, y.Age) }),
+		)
+	})
+
+	payload, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:]), nil
 }
 
 // Stay represents the duration of stay in Hotelbeds request.
@@ -28,6 +80,54 @@ type Stay struct {
 	CheckOut string `json:"checkOut"`
 }
 
+// Destination restricts a search to the hotels of a Hotelbeds destination, or of one of its zones.
+type Destination struct {
+	Code string `json:"code"`
+	Zone int    `json:"zone,omitempty"`
+}
+
+// Geolocation restricts a search to the hotels within Radius of a point.
+type Geolocation struct {
+	Latitude  float64      `json:"latitude"`
+	Longitude float64      `json:"longitude"`
+	Radius    float64      `json:"radius"`
+	Unit      DistanceUnit `json:"unit"`
+}
+
+// DistanceUnit is the unit of a Geolocation radius.
+type DistanceUnit string
+
+const (
+	// UnitKilometers measures distances in kilometers.
+	UnitKilometers DistanceUnit = "km"
+	// UnitMiles measures distances in miles.
+	UnitMiles DistanceUnit = "mi"
+)
+
+// Filter narrows the hotels and rates Hotelbeds returns, zero values are not filtered on.
+type Filter struct {
+	MinRate     float64     `json:"minRate,omitempty"`
+	MaxRate     float64     `json:"maxRate,omitempty"`
+	MinCategory int         `json:"minCategory,omitempty"`
+	PaymentType PaymentType `json:"paymentType,omitempty"`
+}
+
+// PaymentType is where a rate is paid.
+type PaymentType string
+
+const (
+	// PaymentAtWeb rates are paid to Hotelbeds when booking.
+	PaymentAtWeb PaymentType = "AT_WEB"
+	// PaymentAtHotel rates are paid by the guest at the hotel.
+	PaymentAtHotel PaymentType = "AT_HOTEL"
+)
+
+// Boards restricts the rates Hotelbeds returns to the given board codes, or excludes them when Included is false.
+type Boards struct {
+	Board    []string `json:"board"`
+	Included bool     `json:"included"`
+}
+
 // HotelIds is a collection of Hotelbeds hotel Ids.
 type HotelIds struct {
 	Hotel []int `json:"hotel"`
@@ -46,6 +146,12 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// ErrorResponse is the body Hotelbeds returns with detailed errors.
+type ErrorResponse struct {
+	AuditData AuditData `json:"auditData"`
+	Error     Error     `json:"error"`
+}
+
 // SimpleError is the error struct used with 401 and 403 error codes.
 type SimpleError struct {
 	Error string `json:"error"`
@@ -102,6 +208,9 @@ type Room struct {
 	Rates Rates  `json:"rates"`
 }
 
+// RateClassNonRefundable is the rate class Hotelbeds uses for non-refundable rates.
+const RateClassNonRefundable = "NRF"
+
 // Rates is a collection of Rate.
 type Rates []Rate
 
@@ -121,6 +230,7 @@ type Rate struct {
 	Rooms                int                  `json:"rooms"`
 	Adults               int                  `json:"adults"`
 	Children             int                  `json:"children"`
+	RateComments         string               `json:"rateComments,omitempty"`
 }
 
 // CancellationPolicies is a collection of CancellationPolicy.
@@ -150,9 +260,186 @@ type Tax struct {
 	ClientCurrency string `json:"clientCurrency"`
 }
 
+// CheckRateRequest is the request format which Hotelbeds expect to re-price rates in.
+type CheckRateRequest struct {
+	Rooms CheckRateRooms `json:"rooms"`
+}
+
+// CheckRateRooms is a collection of CheckRateRoom.
+type CheckRateRooms []CheckRateRoom
+
+// CheckRateRoom identifies the rate to be re-priced.
+type CheckRateRoom struct {
+	RateKey string `json:"rateKey"`
+}
+
+// CheckRateResponse is the API response model from Hotelbeds for rate checks.
+type CheckRateResponse struct {
+	AuditData AuditData      `json:"auditData"`
+	Hotel     CheckRateHotel `json:"hotel"`
+	Error     Error          `json:"error,omitempty"`
+}
+
+// CheckRateHotel contains the hotel with the confirmed rates.
+type CheckRateHotel struct {
+	Hotel
+	CheckIn  string `json:"checkIn"`
+	CheckOut string `json:"checkOut"`
+	TotalNet string `json:"totalNet"`
+}
+
+// CancellationFlag selects whether a cancellation is only simulated or actually performed.
+type CancellationFlag string
+
+const (
+	// CancellationSimulation returns the cancellation cost without cancelling the booking.
+	CancellationSimulation CancellationFlag = "SIMULATION"
+	// CancellationConfirm cancels the booking.
+	CancellationConfirm CancellationFlag = "CANCELLATION"
+)
+
+// PaxType is the Hotelbeds passenger type.
+type PaxType string
+
+const (
+	// PaxAdult represents an adult passenger.
+	PaxAdult PaxType = "AD"
+	// PaxChild represents a child passenger.
+	PaxChild PaxType = "CH"
+)
+
+// BookingRequest is the request format which Hotelbeds expect bookings in.
+type BookingRequest struct {
+	Holder          Holder       `json:"holder"`
+	Rooms           BookingRooms `json:"rooms"`
+	ClientReference string       `json:"clientReference"`
+	Remark          string       `json:"remark,omitempty"`
+}
+
+// Holder is the person the booking is made for.
+type Holder struct {
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+}
+
+// BookingRooms is a collection of BookingRoom.
+type BookingRooms []BookingRoom
+
+// BookingRoom is the rate to be booked along with its passengers.
+type BookingRoom struct {
+	RateKey string `json:"rateKey,omitempty"`
+	Paxes   Paxes  `json:"paxes"`
+}
+
+// Paxes is a collection of Pax.
+type Paxes []Pax
+
+// Pax represents a passenger.
+type Pax struct {
+	RoomID  int     `json:"roomId,omitempty"`
+	Type    PaxType `json:"type"`
+	Age     int     `json:"age,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Surname string  `json:"surname,omitempty"`
+}
+
+// MarshalJSON always sends the age of children, including infants aged zero.
+func (p Pax) MarshalJSON() ([]byte, error) {
+	type pax Pax
+	if p.Type != PaxChild {
+		return json.Marshal(pax(p))
+	}
+
+	return json.Marshal(struct {
+		pax
+		Age int `json:"age"`
+	}{
+		pax: pax(p),
+		Age: p.Age,
+	})
+}
+
+// BookingResponse is the API response model from Hotelbeds for booking confirmation, detail and cancellation.
+type BookingResponse struct {
+	AuditData AuditData `json:"auditData"`
+	Booking   Booking   `json:"booking"`
+	Error     Error     `json:"error,omitempty"`
+}
+
+// Booking contains the information about a booking.
+type Booking struct {
+	Reference             string       `json:"reference"`
+	CancellationReference string       `json:"cancellationReference,omitempty"`
+	ClientReference       string       `json:"clientReference"`
+	CreationDate          string       `json:"creationDate"`
+	Status                string       `json:"status"`
+	Holder                Holder       `json:"holder"`
+	Hotel                 BookingHotel `json:"hotel"`
+	Remark                string       `json:"remark,omitempty"`
+	TotalNet              float64      `json:"totalNet"`
+	PendingAmount         float64      `json:"pendingAmount"`
+	Currency              string       `json:"currency"`
+}
+
+// BookingHotel contains the booked hotel and rooms.
+type BookingHotel struct {
+	CheckIn            string      `json:"checkIn"`
+	CheckOut           string      `json:"checkOut"`
+	Code               int         `json:"code"`
+	Name               string      `json:"name"`
+	Rooms              BookedRooms `json:"rooms"`
+	TotalNet           string      `json:"totalNet"`
+	Currency           string      `json:"currency"`
+	CancellationAmount float64     `json:"cancellationAmount,omitempty"`
+}
+
+// BookedRooms is a collection of BookedRoom.
+type BookedRooms []BookedRoom
+
+// BookedRoom contains a booked room with its passengers and rates.
+type BookedRoom struct {
+	Status string `json:"status"`
+	ID     int    `json:"id"`
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Paxes  Paxes  `json:"paxes"`
+	Rates  Rates  `json:"rates"`
+}
+
 // HotelBeds is the API client that makes requests to Hotelbeds.
 type HotelBeds interface {
 	// Search searches Hotelbeds with the given SearchRequest and returns SearchResponse if success.
 	// It returns error if any.
 	Search(context.Context, SearchRequest) (SearchResponse, error)
+	// CheckRate re-prices the rates in the given CheckRateRequest and returns CheckRateResponse if success.
+	// It returns error if any.
+	CheckRate(context.Context, CheckRateRequest) (CheckRateResponse, error)
+	// Book confirms the booking in the given BookingRequest and returns BookingResponse if success.
+	// It returns error if any.
+	Book(context.Context, BookingRequest) (BookingResponse, error)
+	// BookingDetail returns the booking with the given reference.
+	// It returns error if any.
+	BookingDetail(ctx context.Context, reference string) (BookingResponse, error)
+	// CancelBooking cancels or simulates cancelling the booking with the given reference depending on flag.
+	// It returns error if any.
+	CancelBooking(ctx context.Context, reference string, flag CancellationFlag) (BookingResponse, error)
+}
+
+// HotelContent is the API client that fetches static hotel data from the Hotelbeds Content API.
+type HotelContent interface {
+	// Hotels returns the page of hotels matching the given ContentRequest.
+	// It returns error if any.
+	Hotels(context.Context, ContentRequest) (ContentHotelsResponse, error)
+	// Countries returns the page of countries matching the given ContentRequest.
+	// It returns error if any.
+	Countries(context.Context, ContentRequest) (ContentCountriesResponse, error)
+	// Destinations returns the page of destinations matching the given ContentRequest.
+	// It returns error if any.
+	Destinations(context.Context, ContentRequest) (ContentDestinationsResponse, error)
+	// Boards returns the page of boards matching the given ContentRequest.
+	// It returns error if any.
+	Boards(context.Context, ContentRequest) (ContentBoardsResponse, error)
+	// RoomTypes returns the page of room types matching the given ContentRequest.
+	// It returns error if any.
+	RoomTypes(context.Context, ContentRequest) (ContentRoomTypesResponse, error)
 }
@@ -0,0 +1,116 @@
+// Package coalesce collapses concurrent identical availability searches into a single Hotelbeds call.
+package coalesce
+
+import (
+	"context"
+	"lite-api/internal/client"
+	"sync"
+)
+
+// Coalescer is a client.HotelBeds decorator which shares one in-flight search between concurrent callers
+// asking for the same client.SearchRequest. Every other call is passed through to the wrapped client.
+//
+// The shared search does not inherit the cancellation of the caller which started it, so that caller going away
+// does not fail the others. It is only cancelled once every caller waiting for it has gone away.
+type Coalescer struct {
+	client.HotelBeds
+
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is an in-flight search shared by waiters callers.
+type call struct {
+	done chan struct{}
+	res  client.SearchResponse
+	err  error
+	// keys are the supplier keys which served res, reported to every waiter.
+	keys    []string
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewCoalescer returns Coalescer wrapping next.
+func NewCoalescer(next client.HotelBeds) *Coalescer {
+	return &Coalescer{
+		HotelBeds: next,
+		calls:     make(map[string]*call),
+	}
+}
+
+// Search joins the in-flight search identical to searchReq or starts a new one.
+// Responses are shared between callers and must not be modified.
+func (c *Coalescer) Search(ctx context.Context, searchReq client.SearchRequest) (client.SearchResponse, error) {
+	key, err := client.SearchKey(ctx, searchReq)
+	if err != nil {
+		return c.HotelBeds.Search(ctx, searchReq)
+	}
+
+	c.mu.Lock()
+	cl, ok := c.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		cl = &call{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		c.calls[key] = cl
+
+		go c.run(callCtx, key, cl, searchReq)
+	}
+
+	cl.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-cl.done:
+		client.RecordKeys(ctx, cl.keys)
+		return cl.res, cl.err
+	case <-ctx.Done():
+		c.leave(key, cl)
+		return client.SearchResponse{}, ctx.Err()
+	}
+}
+
+// InFlight returns the number of distinct searches currently in flight.
+func (c *Coalescer) InFlight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.calls)
+}
+
+func (c *Coalescer) run(ctx context.Context, key string, cl *call, searchReq client.SearchRequest) {
+	defer cl.cancel()
+
+	ctx, keys := client.WithKeyRecorder(ctx)
+	cl.res, cl.err = c.HotelBeds.Search(ctx, searchReq)
+	cl.keys = keys.Keys()
+
+	c.mu.Lock()
+	c.forget(key, cl)
+	c.mu.Unlock()
+
+	close(cl.done)
+}
+
+// leave removes a waiter from cl, cancelling the search when nobody waits for it anymore.
+func (c *Coalescer) leave(key string, cl *call) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl.waiters--
+	if cl.waiters > 0 {
+		return
+	}
+
+	cl.cancel()
+	c.forget(key, cl)
+}
+
+// forget stops new callers from joining cl, it must be called with mu held.
+func (c *Coalescer) forget(key string, cl *call) {
+	if c.calls[key] == cl {
+		delete(c.calls, key)
+	}
+}
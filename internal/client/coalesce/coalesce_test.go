@@ -0,0 +1,218 @@
+package coalesce
+
+import (
+	"context"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func searchRequest(hotelIds ...int) client.SearchRequest {
+	return client.SearchRequest{
+		Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-16"},
+		Occupancies: client.Occupancies{{Rooms: 1, Adults: 2}},
+		Hotels:      &client.HotelIds{Hotel: hotelIds},
+	}
+}
+
+func TestCoalescer_Search(t *testing.T) {
+	expectedResp := client.SearchResponse{Hotels: client.HotelsInfo{Total: 2}}
+
+	t.Run("concurrent identical searches share one call", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		coalescer := NewCoalescer(cliMock)
+
+		release := make(chan struct{})
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, _ client.SearchRequest) (client.SearchResponse, error) {
+				<-release
+				client.RecordKey(ctx, "eu", "2026-q4")
+				return expectedResp, nil
+			})
+
+		const callers = 10
+		var wg sync.WaitGroup
+		results := make([]client.SearchResponse, callers)
+		errs := make([]error, callers)
+		keys := make([][]string, callers)
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Hotel ids in a different order still describe the same search.
+				req := searchRequest(1, 2)
+				if i%2 == 1 {
+					req = searchRequest(2, 1)
+				}
+				ctx, rec := client.WithKeyRecorder(context.Background())
+				results[i], errs[i] = coalescer.Search(ctx, req)
+				keys[i] = rec.Keys()
+			}()
+		}
+
+		key, err := searchRequest(1, 2).Key()
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			coalescer.mu.Lock()
+			defer coalescer.mu.Unlock()
+			cl, ok := coalescer.calls[key]
+			return ok && cl.waiters == callers
+		}, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		for i := range callers {
+			require.NoError(t, errs[i])
+			require.Equal(t, expectedResp, results[i])
+			// Every caller reports the key of the shared call, not only the one which started it.
+			require.Equal(t, []string{"eu/2026-q4"}, keys[i])
+		}
+		require.Zero(t, coalescer.InFlight())
+	})
+
+	t.Run("different searches are not shared", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		coalescer := NewCoalescer(cliMock)
+
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).Return(expectedResp, nil)
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(2)).Return(client.SearchResponse{}, assert.AnError)
+
+		res, err := coalescer.Search(context.Background(), searchRequest(1))
+		require.NoError(t, err)
+		require.Equal(t, expectedResp, res)
+
+		_, err = coalescer.Search(context.Background(), searchRequest(2))
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("credentials with the same api key and other secrets are not shared", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		coalescer := NewCoalescer(cliMock)
+
+		owner := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Credentials: &client.Credentials{ApiKey: "key", Secret: "secret"},
+		})
+		impostor := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Credentials: &client.Credentials{ApiKey: "key", Secret: "wrong"},
+		})
+
+		release := make(chan struct{})
+		cliMock.EXPECT().Search(gomock.Any(), searchRequest(1)).Times(2).
+			DoAndReturn(func(ctx context.Context, _ client.SearchRequest) (client.SearchResponse, error) {
+				if client.SupplierConfigFrom(ctx).Credentials.Secret == "wrong" {
+					return client.SearchResponse{}, assert.AnError
+				}
+
+				<-release
+				return expectedResp, nil
+			})
+
+		done := make(chan error)
+		go func() {
+			_, err := coalescer.Search(owner, searchRequest(1))
+			done <- err
+		}()
+
+		require.Eventually(t, func() bool { return coalescer.InFlight() == 1 }, time.Second, time.Millisecond)
+		_, err := coalescer.Search(impostor, searchRequest(1))
+		require.ErrorIs(t, err, assert.AnError)
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("one caller cancelling does not fail the others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		coalescer := NewCoalescer(cliMock)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, _ client.SearchRequest) (client.SearchResponse, error) {
+				close(started)
+				select {
+				case <-release:
+					return expectedResp, nil
+				case <-ctx.Done():
+					return client.SearchResponse{}, ctx.Err()
+				}
+			})
+
+		firstCtx, cancelFirst := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := coalescer.Search(firstCtx, searchRequest(1))
+			firstErr <- err
+		}()
+		<-started
+
+		secondRes := make(chan client.SearchResponse, 1)
+		secondErr := make(chan error, 1)
+		go func() {
+			res, err := coalescer.Search(context.Background(), searchRequest(1))
+			secondRes <- res
+			secondErr <- err
+		}()
+
+		key, err := searchRequest(1).Key()
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			coalescer.mu.Lock()
+			defer coalescer.mu.Unlock()
+			return coalescer.calls[key].waiters == 2
+		}, time.Second, time.Millisecond)
+		cancelFirst()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+
+		close(release)
+		require.NoError(t, <-secondErr)
+		require.Equal(t, expectedResp, <-secondRes)
+	})
+
+	t.Run("shared call is cancelled when every caller left", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		coalescer := NewCoalescer(cliMock)
+
+		started, upstreamCancelled := make(chan struct{}), make(chan struct{})
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, _ client.SearchRequest) (client.SearchResponse, error) {
+				close(started)
+				<-ctx.Done()
+				close(upstreamCancelled)
+				return client.SearchResponse{}, ctx.Err()
+			})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		callerErr := make(chan error, 1)
+		go func() {
+			_, err := coalescer.Search(ctx, searchRequest(1))
+			callerErr <- err
+		}()
+		<-started
+
+		cancel()
+		require.ErrorIs(t, <-callerErr, context.Canceled)
+
+		select {
+		case <-upstreamCancelled:
+		case <-time.After(time.Second):
+			t.Fatal("shared search was not cancelled")
+		}
+		require.Zero(t, coalescer.InFlight())
+	})
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Credentials authenticate requests to a supplier.
+type Credentials struct {
+	ApiKey string
+	Secret string
+}
+
+// SupplierConfig overrides the configuration of the supplier requests of a single lite API request, zero values
+// keep the configured ones.
+type SupplierConfig struct {
+	// Supplier restricts searches to the supplier of that name.
+	Supplier string
+	// Account is the name of the supplier account requests are signed with.
+	Account string
+	// Environment is the name of the supplier environment requests are sent to, e.g. test or live.
+	Environment string
+	// Credentials replace the configured ones when not nil.
+	Credentials *Credentials
+	// Timeout bounds every supplier request.
+	Timeout time.Duration
+}
+
+// Partition returns an identifier of the environment and account of the config, responses of requests with
+// different partitions must not be shared.
+func (s SupplierConfig) Partition() string {
+	partition := s.Environment
+	if s.Account != "" {
+		partition += "@" + s.Account
+	}
+
+	if s.Credentials != nil {
+		// The API key is sent in plain headers, the secret proves the caller holds the credentials.
+		hash := sha256.Sum256([]byte(s.Credentials.ApiKey + "\x00" + s.Credentials.Secret))
+		partition += "/" + hex.EncodeToString(hash[:])
+	}
+
+	return partition
+}
+
+type supplierConfigKey struct{}
+
+// WithSupplierConfig returns a copy of ctx carrying cfg.
+func WithSupplierConfig(ctx context.Context, cfg SupplierConfig) context.Context {
+	return context.WithValue(ctx, supplierConfigKey{}, cfg)
+}
+
+// SupplierConfigFrom returns the SupplierConfig carried by ctx, the zero value if there is none.
+func SupplierConfigFrom(ctx context.Context) SupplierConfig {
+	cfg, _ := ctx.Value(supplierConfigKey{}).(SupplierConfig)
+	return cfg
+}
+
+type marketKey struct{}
+
+// WithMarket returns a copy of ctx carrying the guest nationality market of its requests, the ISO 3166-1 alpha-2
+// code picking the supplier account of requests without a market of their own, e.g. rate checks and bookings.
+func WithMarket(ctx context.Context, market string) context.Context {
+	return context.WithValue(ctx, marketKey{}, market)
+}
+
+// MarketFrom returns the market carried by ctx, empty if there is none.
+func MarketFrom(ctx context.Context) string {
+	market, _ := ctx.Value(marketKey{}).(string)
+	return market
+}
+
+// SearchKey returns the Key of searchReq within the partition of the SupplierConfig of ctx.
+func SearchKey(ctx context.Context, searchReq SearchRequest) (string, error) {
+	key, err := searchReq.Key()
+	if err != nil {
+		return "", err
+	}
+
+	if partition := SupplierConfigFrom(ctx).Partition(); partition != "" {
+		key = partition + "/" + key
+	}
+
+	return key, nil
+}
+
+// KeyRecorder collects the supplier accounts and keys which served the requests made with its context.
+type KeyRecorder struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+type keyRecorderKey struct{}
+
+// WithKeyRecorder returns a copy of ctx carrying a new KeyRecorder.
+func WithKeyRecorder(ctx context.Context) (context.Context, *KeyRecorder) {
+	rec := &KeyRecorder{}
+	return context.WithValue(ctx, keyRecorderKey{}, rec), rec
+}
+
+// Keys returns the keys which served requests as account/key names, in the order they were first used.
+func (r *KeyRecorder) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.keys)
+}
+
+// RecordKey records on the KeyRecorder of ctx, if any, that the key of account served a request. Keys are
+// identified by name, never by their value.
+func RecordKey(ctx context.Context, account, key string) {
+	rec, ok := ctx.Value(keyRecorderKey{}).(*KeyRecorder)
+	if !ok {
+		return
+	}
+
+	rec.record(account + "/" + key)
+}
+
+// RecordKeys records on the KeyRecorder of ctx, if any, the keys of another KeyRecorder, so that responses shared
+// between requests report the keys which served them.
+func RecordKeys(ctx context.Context, keys []string) {
+	rec, ok := ctx.Value(keyRecorderKey{}).(*KeyRecorder)
+	if !ok {
+		return
+	}
+
+	for _, name := range keys {
+		rec.record(name)
+	}
+}
+
+func (r *KeyRecorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !slices.Contains(r.keys, name) {
+		r.keys = append(r.keys, name)
+	}
+}
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxContentPageSize is the largest page of hotels the Content API returns.
+const MaxContentPageSize = 1000
+
+// ContentRequest is the query of the Hotelbeds Content API list endpoints.
+type ContentRequest struct {
+	// Codes restricts the hotels returned, every hotel is returned when empty. It is ignored by the other lists.
+	Codes []int
+	// Language is the three letter Hotelbeds language code of the descriptions, e.g. ENG.
+	Language string
+	// From and To are the 1-based inclusive positions of the page of hotels returned.
+	From int
+	To   int
+	// LastUpdateTime only returns hotels updated since then, formatted as YYYY-MM-DD.
+	LastUpdateTime string
+}
+
+// ContentPage is the position of a page in a Content API list.
+type ContentPage struct {
+	From  int
+	To    int
+	Total int
+	// Count is the number of items in the page.
+	Count int
+}
+
+// ContentPager is a page of a Content API list.
+type ContentPager interface {
+	Page() ContentPage
+}
+
+// ContentText is a translated text of the Content API.
+type ContentText struct {
+	Content string `json:"content"`
+}
+
+// ContentHotelsResponse is a page of hotels of the Content API.
+type ContentHotelsResponse struct {
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Total     int           `json:"total"`
+	AuditData AuditData     `json:"auditData"`
+	Hotels    ContentHotels `json:"hotels"`
+}
+
+func (c ContentHotelsResponse) Page() ContentPage {
+	return ContentPage{From: c.From, To: c.To, Total: c.Total, Count: len(c.Hotels)}
+}
+
+// ContentHotels is a collection of ContentHotel.
+type ContentHotels []ContentHotel
+
+// ContentHotel is the static data of a hotel.
+type ContentHotel struct {
+	Code                  int                `json:"code"`
+	Name                  ContentText        `json:"name"`
+	Description           ContentText        `json:"description"`
+	CountryCode           string             `json:"countryCode"`
+	StateCode             string             `json:"stateCode"`
+	DestinationCode       string             `json:"destinationCode"`
+	ZoneCode              int                `json:"zoneCode"`
+	Coordinates           ContentCoordinates `json:"coordinates"`
+	CategoryCode          string             `json:"categoryCode"`
+	CategoryGroupCode     string             `json:"categoryGroupCode"`
+	ChainCode             string             `json:"chainCode"`
+	AccommodationTypeCode string             `json:"accommodationTypeCode"`
+	BoardCodes            []string           `json:"boardCodes"`
+	Address               ContentAddress     `json:"address"`
+	PostalCode            string             `json:"postalCode"`
+	City                  ContentText        `json:"city"`
+	Email                 string             `json:"email"`
+	Web                   string             `json:"web"`
+	Phones                ContentPhones      `json:"phones"`
+	Facilities            ContentFacilities  `json:"facilities"`
+	Images                ContentImages      `json:"images"`
+	LastUpdate            string             `json:"lastUpdate"`
+}
+
+// ContentCoordinates is the location of a hotel.
+type ContentCoordinates struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// ContentAddress is the street address of a hotel.
+type ContentAddress struct {
+	Content string `json:"content"`
+	Street  string `json:"street"`
+	Number  string `json:"number"`
+}
+
+// ContentPhones is a collection of ContentPhone.
+type ContentPhones []ContentPhone
+
+// ContentPhone is a phone number of a hotel.
+type ContentPhone struct {
+	PhoneNumber string `json:"phoneNumber"`
+	PhoneType   string `json:"phoneType"`
+}
+
+// ContentFacilities is a collection of ContentFacility.
+type ContentFacilities []ContentFacility
+
+// ContentFacility is a facility offered by a hotel.
+type ContentFacility struct {
+	FacilityCode      int         `json:"facilityCode"`
+	FacilityGroupCode int         `json:"facilityGroupCode"`
+	Description       ContentText `json:"description"`
+	IndFee            bool        `json:"indFee"`
+}
+
+// ContentImages is a collection of ContentImage.
+type ContentImages []ContentImage
+
+// ContentImage is a picture of a hotel, Path is relative to the Hotelbeds photos host.
+type ContentImage struct {
+	ImageTypeCode string `json:"imageTypeCode"`
+	Path          string `json:"path"`
+	Order         int    `json:"order"`
+	VisualOrder   int    `json:"visualOrder"`
+	RoomCode      string `json:"roomCode,omitempty"`
+}
+
+// ContentCountriesResponse is a page of countries of the Content API.
+type ContentCountriesResponse struct {
+	From      int              `json:"from"`
+	To        int              `json:"to"`
+	Total     int              `json:"total"`
+	AuditData AuditData        `json:"auditData"`
+	Countries ContentCountries `json:"countries"`
+}
+
+func (c ContentCountriesResponse) Page() ContentPage {
+	return ContentPage{From: c.From, To: c.To, Total: c.Total, Count: len(c.Countries)}
+}
+
+// ContentCountries is a collection of ContentCountry.
+type ContentCountries []ContentCountry
+
+// ContentCountry is a country hotels are located in.
+type ContentCountry struct {
+	Code        string      `json:"code"`
+	IsoCode     string      `json:"isoCode"`
+	Description ContentText `json:"description"`
+}
+
+// ContentDestinationsResponse is a page of destinations of the Content API.
+type ContentDestinationsResponse struct {
+	From         int                 `json:"from"`
+	To           int                 `json:"to"`
+	Total        int                 `json:"total"`
+	AuditData    AuditData           `json:"auditData"`
+	Destinations ContentDestinations `json:"destinations"`
+}
+
+func (c ContentDestinationsResponse) Page() ContentPage {
+	return ContentPage{From: c.From, To: c.To, Total: c.Total, Count: len(c.Destinations)}
+}
+
+// ContentDestinations is a collection of ContentDestination.
+type ContentDestinations []ContentDestination
+
+// ContentDestination is a Hotelbeds destination, usually a city or a region, divided in zones.
+type ContentDestination struct {
+	Code        string       `json:"code"`
+	Name        ContentText  `json:"name"`
+	CountryCode string       `json:"countryCode"`
+	IsoCode     string       `json:"isoCode"`
+	Zones       ContentZones `json:"zones"`
+}
+
+// ContentZones is a collection of ContentZone.
+type ContentZones []ContentZone
+
+// ContentZone is an area of a destination.
+type ContentZone struct {
+	ZoneCode    int         `json:"zoneCode"`
+	Name        string      `json:"name"`
+	Description ContentText `json:"description"`
+}
+
+// ContentBoardsResponse is a page of boards of the Content API.
+type ContentBoardsResponse struct {
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Total     int           `json:"total"`
+	AuditData AuditData     `json:"auditData"`
+	Boards    ContentBoards `json:"boards"`
+}
+
+func (c ContentBoardsResponse) Page() ContentPage {
+	return ContentPage{From: c.From, To: c.To, Total: c.Total, Count: len(c.Boards)}
+}
+
+// ContentBoards is a collection of ContentBoard.
+type ContentBoards []ContentBoard
+
+// ContentBoard is a meal plan, e.g. BB for bed and breakfast.
+type ContentBoard struct {
+	Code             string      `json:"code"`
+	Description      ContentText `json:"description"`
+	MultiLingualCode string      `json:"multiLingualCode"`
+}
+
+// ContentRoomTypesResponse is a page of room types of the Content API.
+type ContentRoomTypesResponse struct {
+	From      int              `json:"from"`
+	To        int              `json:"to"`
+	Total     int              `json:"total"`
+	AuditData AuditData        `json:"auditData"`
+	Rooms     ContentRoomTypes `json:"rooms"`
+}
+
+func (c ContentRoomTypesResponse) Page() ContentPage {
+	return ContentPage{From: c.From, To: c.To, Total: c.Total, Count: len(c.Rooms)}
+}
+
+// ContentRoomTypes is a collection of ContentRoomType.
+type ContentRoomTypes []ContentRoomType
+
+// ContentRoomType is a room type, e.g. DBL.ST for a standard double room.
+type ContentRoomType struct {
+	Code                      string      `json:"code"`
+	Type                      string      `json:"type"`
+	Characteristic            string      `json:"characteristic"`
+	MinPax                    int         `json:"minPax"`
+	MaxPax                    int         `json:"maxPax"`
+	MaxAdults                 int         `json:"maxAdults"`
+	MaxChildren               int         `json:"maxChildren"`
+	MinAdults                 int         `json:"minAdults"`
+	Description               string      `json:"description"`
+	TypeDescription           ContentText `json:"typeDescription"`
+	CharacteristicDescription ContentText `json:"characteristicDescription"`
+}
+
+// StarRating returns the number of stars of a Hotelbeds category code, zero for categories without stars.
+// Codes start with the number of stars, e.g. 4EST or 3LL, or encode half stars as H3_5.
+func StarRating(categoryCode string) float64 {
+	if strings.HasPrefix(categoryCode, "H") {
+		if stars, err := strconv.ParseFloat(strings.Replace(categoryCode[1:], "_", ".", 1), 64); err == nil {
+			return stars
+		}
+
+		return 0
+	}
+
+	if categoryCode == "" || !unicode.IsDigit(rune(categoryCode[0])) {
+		return 0
+	}
+
+	return float64(categoryCode[0] - '0')
+}
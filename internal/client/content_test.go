@@ -0,0 +1,28 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarRating(t *testing.T) {
+	tests := []struct {
+		categoryCode string
+		want         float64
+	}{
+		{"5EST", 5},
+		{"3LL", 3},
+		{"H3_5", 3.5},
+		{"H4", 4},
+		{"HS", 0},
+		{"BB", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.categoryCode, func(t *testing.T) {
+			require.Equal(t, tt.want, StarRating(tt.categoryCode))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package hotelbeds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultAccount is the name of the account used when a request is not matched to another one. It is signed
+	// with the API key and secret HotelBeds is created with unless the accounts define it.
+	DefaultAccount = "default"
+	// RequestKey is the name reported for the credentials of a request's client.SupplierConfig.
+	RequestKey = "request"
+
+	// ErrCodeUnknownAccount is the code of the error returned for a request naming an account which is not defined.
+	ErrCodeUnknownAccount = "unknown_account"
+)
+
+// ErrInvalidAccounts is returned for accounts which cannot be used.
+var ErrInvalidAccounts = errors.New("invalid hotelbeds accounts")
+
+// Key is an API key of a Hotelbeds account.
+type Key struct {
+	// Name identifies the key in logs and responses without revealing it, e.g. 2026-q4.
+	Name   string `json:"name" yaml:"name"`
+	ApiKey string `json:"apiKey" yaml:"apiKey"`
+	Secret string `json:"secret" yaml:"secret"`
+}
+
+// Account is a Hotelbeds account, e.g. the one of a market.
+type Account struct {
+	Name string `json:"name" yaml:"name"`
+	// Keys are tried in order, the next one is tried when Hotelbeds refuses a key with 401 or 403. Listing the new
+	// key along with the old one lets keys be rotated without downtime.
+	Keys []Key `json:"keys" yaml:"keys"`
+	// Nationalities are the ISO 3166-1 alpha-2 guest nationalities whose searches use the account unless the
+	// request names one.
+	Nationalities []string `json:"nationalities,omitempty" yaml:"nationalities"`
+}
+
+// account is an Account along with the key which last served one of its requests, tried first.
+type account struct {
+	name      string
+	keys      []Key
+	preferred atomic.Int32
+}
+
+// Accounts is a set of validated Hotelbeds accounts.
+type Accounts struct {
+	accounts      map[string]*account
+	byNationality map[string]*account
+}
+
+// NewAccounts validates accounts, names, nationalities and the names of the keys of an account must be unique.
+func NewAccounts(accounts []Account) (*Accounts, error) {
+	a := &Accounts{
+		accounts:      make(map[string]*account, len(accounts)),
+		byNationality: make(map[string]*account),
+	}
+
+	for i, acc := range accounts {
+		if acc.Name == "" {
+			return nil, fmt.Errorf("%w: account %d has no name", ErrInvalidAccounts, i+1)
+		}
+
+		if _, ok := a.accounts[acc.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate account %q", ErrInvalidAccounts, acc.Name)
+		}
+
+		if err := validateKeys(acc.Keys); err != nil {
+			return nil, fmt.Errorf("%w: account %q: %w", ErrInvalidAccounts, acc.Name, err)
+		}
+
+		validated := &account{name: acc.Name, keys: slices.Clone(acc.Keys)}
+		a.accounts[acc.Name] = validated
+
+		for _, nationality := range acc.Nationalities {
+			nationality = strings.ToUpper(nationality)
+			if len(nationality) != 2 {
+				return nil, fmt.Errorf("%w: account %q: nationality %q is not an ISO 3166-1 alpha-2 code",
+					ErrInvalidAccounts, acc.Name, nationality)
+			}
+
+			if other, ok := a.byNationality[nationality]; ok {
+				return nil, fmt.Errorf("%w: nationality %s belongs to accounts %q and %q", ErrInvalidAccounts,
+					nationality, other.name, acc.Name)
+			}
+
+			a.byNationality[nationality] = validated
+		}
+	}
+
+	return a, nil
+}
+
+// validateKeys checks keys has at least one complete key and no duplicate name.
+func validateKeys(keys []Key) error {
+	if len(keys) == 0 {
+		return errors.New("no key")
+	}
+
+	names := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if key.Name == "" {
+			return fmt.Errorf("key %d has no name", i+1)
+		}
+
+		if names[key.Name] {
+			return fmt.Errorf("duplicate key %q", key.Name)
+		}
+		names[key.Name] = true
+
+		if key.ApiKey == "" || key.Secret == "" {
+			return fmt.Errorf("key %q requires both apiKey and secret", key.Name)
+		}
+	}
+
+	return nil
+}
+
+// LoadAccounts reads the accounts of the YAML or JSON file at path, by its extension. A missing file gives no
+// accounts, every request then uses the default one.
+func LoadAccounts(path string) (*Accounts, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewAccounts(nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Accounts []Account `json:"accounts" yaml:"accounts"`
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(raw, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &file)
+	default:
+		return nil, fmt.Errorf("%w: %s is neither a YAML nor a JSON file", ErrInvalidAccounts, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccounts, err)
+	}
+
+	return NewAccounts(file.Accounts)
+}
+
+// Len returns the number of accounts.
+func (a *Accounts) Len() int {
+	if a == nil {
+		return 0
+	}
+
+	return len(a.accounts)
+}
+
+// lookup returns the account called name, false if there is none.
+func (a *Accounts) lookup(name string) (*account, bool) {
+	if a == nil {
+		return nil, false
+	}
+
+	acc, ok := a.accounts[name]
+	return acc, ok
+}
+
+// forNationality returns the account of the guest nationality, false if there is none.
+func (a *Accounts) forNationality(nationality string) (*account, bool) {
+	if a == nil || nationality == "" {
+		return nil, false
+	}
+
+	acc, ok := a.byNationality[strings.ToUpper(nationality)]
+	return acc, ok
+}
@@ -0,0 +1,111 @@
+package hotelbeds
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccounts(t *testing.T) {
+	key := Key{Name: "2026-q4", ApiKey: "key", Secret: "secret"}
+
+	t.Run("valid accounts", func(t *testing.T) {
+		accounts, err := NewAccounts([]Account{
+			{Name: "eu", Keys: []Key{key}, Nationalities: []string{"es", "FR"}},
+			{Name: "us", Keys: []Key{key}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, accounts.Len())
+
+		acc, ok := accounts.forNationality("ES")
+		require.True(t, ok)
+		require.Equal(t, "eu", acc.name)
+
+		_, ok = accounts.forNationality("US")
+		require.False(t, ok)
+	})
+
+	tests := []struct {
+		name     string
+		accounts []Account
+	}{
+		{"no name", []Account{{Keys: []Key{key}}}},
+		{"duplicate name", []Account{{Name: "eu", Keys: []Key{key}}, {Name: "eu", Keys: []Key{key}}}},
+		{"no key", []Account{{Name: "eu"}}},
+		{"key without name", []Account{{Name: "eu", Keys: []Key{{ApiKey: "key", Secret: "secret"}}}}},
+		{"duplicate key", []Account{{Name: "eu", Keys: []Key{key, key}}}},
+		{"key without secret", []Account{{Name: "eu", Keys: []Key{{Name: "2026-q4", ApiKey: "key"}}}}},
+		{"invalid nationality", []Account{{Name: "eu", Keys: []Key{key}, Nationalities: []string{"ESP"}}}},
+		{"nationality of two accounts", []Account{
+			{Name: "eu", Keys: []Key{key}, Nationalities: []string{"ES"}},
+			{Name: "es", Keys: []Key{key}, Nationalities: []string{"ES"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAccounts(tt.accounts)
+			require.ErrorIs(t, err, ErrInvalidAccounts)
+		})
+	}
+}
+
+func TestLoadAccounts(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		accounts, err := LoadAccounts(filepath.Join(t.TempDir(), "accounts.yaml"))
+		require.NoError(t, err)
+		require.Zero(t, accounts.Len())
+	})
+
+	files := map[string]string{
+		"accounts.yaml": `
+accounts:
+  - name: eu
+    nationalities: [ES]
+    keys:
+      - name: 2026-q4
+        apiKey: new-key
+        secret: new-secret
+      - name: 2026-q3
+        apiKey: old-key
+        secret: old-secret
+`,
+		"accounts.json": `{"accounts": [{"name": "eu", "nationalities": ["ES"], "keys": [
+			{"name": "2026-q4", "apiKey": "new-key", "secret": "new-secret"},
+			{"name": "2026-q3", "apiKey": "old-key", "secret": "old-secret"}]}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			accounts, err := LoadAccounts(path)
+			require.NoError(t, err)
+			acc, ok := accounts.lookup("eu")
+			require.True(t, ok)
+			require.Equal(t, []Key{
+				{Name: "2026-q4", ApiKey: "new-key", Secret: "new-secret"},
+				{Name: "2026-q3", ApiKey: "old-key", Secret: "old-secret"},
+			}, acc.keys)
+		})
+	}
+
+	t.Run("unknown extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "accounts.toml")
+		require.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+
+		_, err := LoadAccounts(path)
+		require.ErrorIs(t, err, ErrInvalidAccounts)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "accounts.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("accounts: {"), 0o600))
+
+		_, err := LoadAccounts(path)
+		require.ErrorIs(t, err, ErrInvalidAccounts)
+	})
+}
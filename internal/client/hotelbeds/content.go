@@ -0,0 +1,137 @@
+package hotelbeds
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.nhat.io/clock"
+)
+
+const (
+	contentHotelsEndpoint       = "/hotel-content-api/1.0/hotels"
+	contentCountriesEndpoint    = "/hotel-content-api/1.0/locations/countries"
+	contentDestinationsEndpoint = "/hotel-content-api/1.0/locations/destinations"
+	contentBoardsEndpoint       = "/hotel-content-api/1.0/types/boards"
+	contentRoomTypesEndpoint    = "/hotel-content-api/1.0/types/rooms"
+
+	// DefaultContentLanguage is the language of the content when the request does not ask for one.
+	DefaultContentLanguage = "ENG"
+)
+
+// Content is the Hotelbeds Content API client. It shares the signing, transport, retries and timeouts of
+// HotelBeds, but has a rate limit of its own since Hotelbeds accounts the Content API quota separately.
+type Content struct {
+	hb *HotelBeds
+}
+
+// NewContent returns Content sending requests to host, it fails if the transport options are invalid.
+func NewContent(host, apiKey, secret string, opts Options, clock clock.Clock, logger *slog.Logger) (*Content, error) {
+	hb, err := NewHotelBeds(host, apiKey, secret, opts, clock, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Content{hb: hb}, nil
+}
+
+// Hotels returns the page of hotels matching contentReq with every field, retrying transient failures.
+func (c *Content) Hotels(ctx context.Context, contentReq client.ContentRequest) (client.ContentHotelsResponse, error) {
+	var contentResp client.ContentHotelsResponse
+	if err := c.list(ctx, contentHotelsEndpoint, contentReq, &contentResp); err != nil {
+		return client.ContentHotelsResponse{}, err
+	}
+
+	return contentResp, nil
+}
+
+// Countries returns the page of countries matching contentReq, retrying transient failures.
+func (c *Content) Countries(ctx context.Context, contentReq client.ContentRequest) (client.ContentCountriesResponse, error) {
+	var contentResp client.ContentCountriesResponse
+	if err := c.list(ctx, contentCountriesEndpoint, contentReq, &contentResp); err != nil {
+		return client.ContentCountriesResponse{}, err
+	}
+
+	return contentResp, nil
+}
+
+// Destinations returns the page of destinations matching contentReq, retrying transient failures.
+func (c *Content) Destinations(ctx context.Context, contentReq client.ContentRequest) (client.ContentDestinationsResponse, error) {
+	var contentResp client.ContentDestinationsResponse
+	if err := c.list(ctx, contentDestinationsEndpoint, contentReq, &contentResp); err != nil {
+		return client.ContentDestinationsResponse{}, err
+	}
+
+	return contentResp, nil
+}
+
+// Boards returns the page of boards matching contentReq, retrying transient failures.
+func (c *Content) Boards(ctx context.Context, contentReq client.ContentRequest) (client.ContentBoardsResponse, error) {
+	var contentResp client.ContentBoardsResponse
+	if err := c.list(ctx, contentBoardsEndpoint, contentReq, &contentResp); err != nil {
+		return client.ContentBoardsResponse{}, err
+	}
+
+	return contentResp, nil
+}
+
+// RoomTypes returns the page of room types matching contentReq, retrying transient failures.
+func (c *Content) RoomTypes(ctx context.Context, contentReq client.ContentRequest) (client.ContentRoomTypesResponse, error) {
+	var contentResp client.ContentRoomTypesResponse
+	if err := c.list(ctx, contentRoomTypesEndpoint, contentReq, &contentResp); err != nil {
+		return client.ContentRoomTypesResponse{}, err
+	}
+
+	return contentResp, nil
+}
+
+// list fetches the page of the Content API list at endpoint matching contentReq with every field into out.
+// Content requests are idempotent and are retried like availability requests.
+func (c *Content) list(ctx context.Context, endpoint string, contentReq client.ContentRequest, out any) error {
+	language := contentReq.Language
+	if language == "" {
+		language = DefaultContentLanguage
+	}
+
+	query := url.Values{
+		"fields":               []string{"all"},
+		"language":             []string{language},
+		"useSecondaryLanguage": []string{"false"},
+	}
+
+	if contentReq.From > 0 {
+		query.Set("from", strconv.Itoa(contentReq.From))
+	}
+
+	if contentReq.To > 0 {
+		query.Set("to", strconv.Itoa(contentReq.To))
+	}
+
+	if len(contentReq.Codes) > 0 && endpoint == contentHotelsEndpoint {
+		codes := make([]string, 0, len(contentReq.Codes))
+		for _, code := range contentReq.Codes {
+			codes = append(codes, strconv.Itoa(code))
+		}
+
+		query.Set("codes", strings.Join(codes, ","))
+	}
+
+	if contentReq.LastUpdateTime != "" {
+		query.Set("lastUpdateTime", contentReq.LastUpdateTime)
+	}
+
+	endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	return c.hb.retry(ctx, func() error {
+		return c.hb.do(ctx, http.MethodGet, endpoint, c.hb.timeouts.Search, "", nil, out)
+	})
+}
+
+// QuotaUsage returns the Content API requests sent during the current UTC day.
+func (c *Content) QuotaUsage() QuotaUsage {
+	return c.hb.QuotaUsage()
+}
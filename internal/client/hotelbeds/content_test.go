@@ -0,0 +1,155 @@
+package hotelbeds
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"lite-api/internal/client"
+	liteapierrors "lite-api/internal/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+)
+
+//go:embed testdata/hotelbeds_content_response.json
+var hotelbedsContentResponse []byte
+
+func TestContent_Hotels(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	apiKey, secret := "12345", "6789"
+
+	t.Run("success", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, contentHotelsEndpoint, r.URL.Path)
+			require.Equal(t, "all", r.URL.Query().Get("fields"))
+			require.Equal(t, "CAS", r.URL.Query().Get("language"))
+			require.Equal(t, "false", r.URL.Query().Get("useSecondaryLanguage"))
+			require.Equal(t, "1", r.URL.Query().Get("from"))
+			require.Equal(t, "2", r.URL.Query().Get("to"))
+			require.Equal(t, "1067,1068", r.URL.Query().Get("codes"))
+			require.Equal(t, "2024-06-01", r.URL.Query().Get("lastUpdateTime"))
+			require.Equal(t, apiKey, r.Header.Get(headerApiKey))
+			require.NotEmpty(t, r.Header.Get(headerXSignature))
+
+			_, _ = w.Write(hotelbedsContentResponse)
+		}))
+		defer mockServer.Close()
+
+		content, err := NewContent(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+
+		res, err := content.Hotels(context.Background(), client.ContentRequest{
+			Codes:          []int{1067, 1068},
+			Language:       "CAS",
+			From:           1,
+			To:             2,
+			LastUpdateTime: "2024-06-01",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, res.Total)
+		require.Len(t, res.Hotels, 2)
+
+		hotel := res.Hotels[0]
+		require.Equal(t, 1067, hotel.Code)
+		require.Equal(t, "Hotel Bellevue", hotel.Name.Content)
+		require.Equal(t, "3EST", hotel.CategoryCode)
+		require.Equal(t, 39.563378, hotel.Coordinates.Latitude)
+		require.Equal(t, "Calle Mayor", hotel.Address.Street)
+		require.Len(t, hotel.Facilities, 2)
+		require.Equal(t, "Wi-fi", hotel.Facilities[1].Description.Content)
+		require.Equal(t, "DBL.ST", hotel.Images[1].RoomCode)
+		require.Equal(t, 1, content.QuotaUsage().Used)
+	})
+
+	t.Run("default language and no paging", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, DefaultContentLanguage, r.URL.Query().Get("language"))
+			require.False(t, r.URL.Query().Has("from"))
+			require.False(t, r.URL.Query().Has("to"))
+			require.False(t, r.URL.Query().Has("codes"))
+			require.False(t, r.URL.Query().Has("lastUpdateTime"))
+
+			_, _ = w.Write(hotelbedsContentResponse)
+		}))
+		defer mockServer.Close()
+
+		content, err := NewContent(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+
+		_, err = content.Hotels(context.Background(), client.ContentRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprintln(w, `{"error": "Access to this API has been disallowed"}`)
+		}))
+		defer mockServer.Close()
+
+		content, err := NewContent(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+
+		res, err := content.Hotels(context.Background(), client.ContentRequest{})
+		require.Zero(t, res)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindSupplierAuth, apiErr.Kind())
+	})
+
+	t.Run("invalid transport", func(t *testing.T) {
+		content, err := NewContent("http://localhost", apiKey, secret, Options{
+			Transport: Transport{ProxyURL: "://proxy"},
+		}, staticClock, nil)
+		require.Error(t, err)
+		require.Nil(t, content)
+	})
+}
+
+func TestContent_Lists(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	bodies := map[string]string{
+		contentCountriesEndpoint:    `{"from": 1, "to": 1, "total": 1, "countries": [{"code": "ES", "isoCode": "ES", "description": {"content": "Spain"}}]}`,
+		contentDestinationsEndpoint: `{"from": 1, "to": 1, "total": 1, "destinations": [{"code": "PMI", "name": {"content": "Majorca"}, "countryCode": "ES", "isoCode": "ES", "zones": [{"zoneCode": 20, "name": "Palma"}]}]}`,
+		contentBoardsEndpoint:       `{"from": 1, "to": 1, "total": 1, "boards": [{"code": "BB", "description": {"content": "BED AND BREAKFAST"}, "multiLingualCode": "BB"}]}`,
+		contentRoomTypesEndpoint:    `{"from": 1, "to": 1, "total": 1, "rooms": [{"code": "DBL.ST", "type": "DBL", "characteristic": "ST", "maxPax": 2, "description": "DOUBLE STANDARD"}]}`,
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		require.True(t, ok, r.URL.Path)
+		require.False(t, r.URL.Query().Has("codes"))
+		require.Equal(t, "2024-06-01", r.URL.Query().Get("lastUpdateTime"))
+
+		_, _ = fmt.Fprintln(w, body)
+	}))
+	defer mockServer.Close()
+
+	content, err := NewContent(mockServer.URL, "12345", "6789", Options{}, staticClock, nil)
+	require.NoError(t, err)
+	req := client.ContentRequest{Codes: []int{1}, LastUpdateTime: "2024-06-01"}
+
+	countries, err := content.Countries(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, client.ContentPage{From: 1, To: 1, Total: 1, Count: 1}, countries.Page())
+	require.Equal(t, "Spain", countries.Countries[0].Description.Content)
+
+	destinations, err := content.Destinations(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "Majorca", destinations.Destinations[0].Name.Content)
+	require.Equal(t, 20, destinations.Destinations[0].Zones[0].ZoneCode)
+
+	boards, err := content.Boards(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "BED AND BREAKFAST", boards.Boards[0].Description.Content)
+
+	roomTypes, err := content.RoomTypes(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "DBL.ST", roomTypes.Rooms[0].Code)
+	require.Equal(t, 2, roomTypes.Rooms[0].MaxPax)
+}
@@ -7,12 +7,17 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"lite-api/internal/client"
 	liteapierrors "lite-api/internal/errors"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"go.nhat.io/clock"
@@ -20,6 +25,8 @@ import (
 
 const (
 	hotelsEndpoint        = "/hotel-api/1.0/hotels"
+	checkRatesEndpoint    = "/hotel-api/1.0/checkrates"
+	bookingsEndpoint      = "/hotel-api/1.0/bookings"
 	headerXSignature      = "X-Signature"
 	headerApiKey          = "Api-key"
 	headerAccept          = "Accept"
@@ -28,56 +35,298 @@ const (
 	headerContentEncoding = "Content-Encoding"
 	applicationJSON       = "application/json"
 	gzipEncoding          = "gzip"
+	cancellationFlagParam = "cancellationFlag"
+	headerRetryAfter      = "Retry-After"
+
+	// ErrCodeUnknownEnvironment is the code of the error returned for a request to an environment without a host.
+	ErrCodeUnknownEnvironment = "unknown_environment"
 )
 
+// Options tunes the behaviour of HotelBeds, the zero value makes a single attempt per request.
+type Options struct {
+	// Retry is the retry policy of availability requests.
+	Retry RetryPolicy
+	// RateLimit is the client-side quota of requests.
+	RateLimit RateLimit
+	// Transport configures the HTTP connections.
+	Transport Transport
+	// Timeouts bounds the duration of requests per operation.
+	Timeouts Timeouts
+	// Environments maps the environment names a request may pick with its client.SupplierConfig to their host.
+	Environments map[string]string
+	// Accounts are the accounts requests may be signed with besides the default one.
+	Accounts *Accounts
+}
+
 type HotelBeds struct {
-	clock  clock.Clock
-	cli    *http.Client
-	logger *slog.Logger
-	apiKey string
-	secret string
-	host   string
+	clock       clock.Clock
+	cli         *http.Client
+	logger      *slog.Logger
+	accounts    *Accounts
+	defaultAcc  *account
+	host        string
+	hosts       map[string]string
+	timeouts    Timeouts
+	retryPolicy RetryPolicy
+	budget      *retryBudget
+	// limiters are the limiters of host and of the hosts of the environments requests may pick.
+	limiters map[string]*limiter
+	sleep    func(ctx context.Context, d time.Duration) error
+	random   func() float64
 }
 
-func NewHotelBeds(host, apiKey, secret string, clock clock.Clock, logger *slog.Logger) *HotelBeds {
-	if host[len(host)-1] == '/' {
-		host = host[:len(host)-1]
+// NewHotelBeds returns HotelBeds sending requests to host, signed with apiKey and secret unless the options define
+// the DefaultAccount. It fails if the transport options are invalid.
+func NewHotelBeds(host, apiKey, secret string, opts Options, clock clock.Clock, logger *slog.Logger) (*HotelBeds, error) {
+	host = strings.TrimSuffix(host, "/")
+	hosts := make(map[string]string, len(opts.Environments))
+	// The quota is the one of a host, test requests must not use up the live one but environments sending requests
+	// to the same host share it.
+	limiters := map[string]*limiter{host: newLimiter(opts.RateLimit)}
+	for environment, environmentHost := range opts.Environments {
+		environmentHost = strings.TrimSuffix(environmentHost, "/")
+		hosts[environment] = environmentHost
+		if _, ok := limiters[environmentHost]; !ok {
+			limiters[environmentHost] = newLimiter(opts.RateLimit)
+		}
+	}
+
+	defaultAcc, ok := opts.Accounts.lookup(DefaultAccount)
+	if !ok {
+		defaultAcc = &account{name: DefaultAccount, keys: []Key{{Name: DefaultAccount, ApiKey: apiKey, Secret: secret}}}
+	}
+
+	httpClient, err := newHTTPClient(opts.Transport)
+	if err != nil {
+		return nil, err
 	}
 
 	return &HotelBeds{
-		cli:    &http.Client{Timeout: time.Second * 5},
-		logger: logger,
-		apiKey: apiKey,
-		secret: secret,
-		host:   host,
-		clock:  clock,
+		cli:         httpClient,
+		logger:      logger,
+		accounts:    opts.Accounts,
+		defaultAcc:  defaultAcc,
+		host:        host,
+		hosts:       hosts,
+		clock:       clock,
+		timeouts:    opts.Timeouts.withDefaults(),
+		retryPolicy: opts.Retry,
+		budget:      newRetryBudget(opts.Retry.BudgetRatio),
+		limiters:    limiters,
+		sleep:       sleepContext,
+		random:      rand.Float64,
+	}, nil
+}
+
+// ForeignEnvironments returns the environments sending requests to another host than the default one.
+func (h *HotelBeds) ForeignEnvironments() []string {
+	var environments []string
+	for environment, host := range h.hosts {
+		if host != h.host {
+			environments = append(environments, environment)
+		}
 	}
+	slices.Sort(environments)
+
+	return environments
 }
 
+// Search searches availability, retrying transient failures according to the retry policy
+// since availability requests are idempotent.
 func (h *HotelBeds) Search(ctx context.Context, searchReq client.SearchRequest) (client.SearchResponse, error) {
-	reqbody, err := json.Marshal(&searchReq)
+	var searchResp client.SearchResponse
+	err := h.retry(ctx, func() error {
+		searchResp = client.SearchResponse{}
+		return h.do(ctx, http.MethodPost, hotelsEndpoint, h.timeouts.Search, searchReq.SourceMarket, &searchReq,
+			&searchResp)
+	})
 	if err != nil {
 		return client.SearchResponse{}, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", h.host,
-		hotelsEndpoint), bytes.NewBuffer(reqbody))
+	return searchResp, nil
+}
+
+// CheckRate re-prices the rate keys in checkRateReq and returns the confirmed rates.
+func (h *HotelBeds) CheckRate(ctx context.Context, checkRateReq client.CheckRateRequest) (client.CheckRateResponse, error) {
+	var checkRateResp client.CheckRateResponse
+	if err := h.do(ctx, http.MethodPost, checkRatesEndpoint, h.timeouts.Search, "", &checkRateReq, &checkRateResp); err != nil {
+		return client.CheckRateResponse{}, err
+	}
+
+	return checkRateResp, nil
+}
+
+// Book confirms the booking of the rates in bookingReq.
+func (h *HotelBeds) Book(ctx context.Context, bookingReq client.BookingRequest) (client.BookingResponse, error) {
+	var bookingResp client.BookingResponse
+	if err := h.do(ctx, http.MethodPost, bookingsEndpoint, h.timeouts.Booking, "", &bookingReq, &bookingResp); err != nil {
+		return client.BookingResponse{}, err
+	}
 
+	return bookingResp, nil
+}
+
+// BookingDetail fetches the booking with the given reference.
+func (h *HotelBeds) BookingDetail(ctx context.Context, reference string) (client.BookingResponse, error) {
+	var bookingResp client.BookingResponse
+	endpoint := fmt.Sprintf("%s/%s", bookingsEndpoint, url.PathEscape(reference))
+	if err := h.do(ctx, http.MethodGet, endpoint, h.timeouts.Booking, "", nil, &bookingResp); err != nil {
+		return client.BookingResponse{}, err
+	}
+
+	return bookingResp, nil
+}
+
+// CancelBooking cancels the booking with the given reference, or only simulates it when flag is CancellationSimulation.
+func (h *HotelBeds) CancelBooking(ctx context.Context, reference string, flag client.CancellationFlag) (client.BookingResponse, error) {
+	var bookingResp client.BookingResponse
+	query := url.Values{cancellationFlagParam: []string{string(flag)}}
+	endpoint := fmt.Sprintf("%s/%s?%s", bookingsEndpoint, url.PathEscape(reference), query.Encode())
+	if err := h.do(ctx, http.MethodDelete, endpoint, h.timeouts.Booking, "", nil, &bookingResp); err != nil {
+		return client.BookingResponse{}, err
+	}
+
+	return bookingResp, nil
+}
+
+// do sends a signed request to the Hotelbeds endpoint and decodes the response into out.
+// reqBody is sent as JSON when not nil. The request is signed with the account of the client.SupplierConfig of ctx,
+// or else of the guest nationality market, the one of ctx when market is empty, falling back to the next key of the
+// account while Hotelbeds refuses them.
+// timeout bounds every attempt once the rate limiter let it through, unless the client.SupplierConfig of ctx has a
+// timeout of its own.
+func (h *HotelBeds) do(ctx context.Context, method, endpoint string, timeout time.Duration, market string, reqBody,
+	out any) error {
+	cfg := client.SupplierConfigFrom(ctx)
+	host := h.host
+	if cfg.Environment != "" {
+		var ok bool
+		if host, ok = h.hosts[cfg.Environment]; !ok {
+			return liteapierrors.NewInvalidRequestErr(ErrCodeUnknownEnvironment,
+				fmt.Sprintf("no Hotelbeds host is configured for the %s environment", cfg.Environment))
+		}
+	}
+
+	if market == "" {
+		market = client.MarketFrom(ctx)
+	}
+
+	acc, err := h.account(cfg, market)
 	if err != nil {
-		return client.SearchResponse{}, err
+		return err
+	}
+
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
 	}
 
-	req.Header.Add(headerApiKey, h.apiKey)
+	var payload []byte
+	if reqBody != nil {
+		if payload, err = json.Marshal(reqBody); err != nil {
+			return err
+		}
+	}
+
+	preferred := int(acc.preferred.Load())
+	for i := range acc.keys {
+		index := (preferred + i) % len(acc.keys)
+		key := acc.keys[index]
+		err = h.send(ctx, method, host+endpoint, timeout, key, payload, out)
+		if err == nil {
+			acc.preferred.Store(int32(index))
+			client.RecordKey(ctx, acc.name, key.Name)
+			return nil
+		}
+
+		if !refused(err) || i == len(acc.keys)-1 {
+			return err
+		}
+
+		if h.logger != nil {
+			h.logger.Warn("hotelbeds refused key, trying the next one", "account", acc.name, "key", key.Name,
+				"err", err)
+		}
+	}
+
+	return err
+}
+
+// account returns the account requests are signed with: the credentials of cfg, the account it names, the account
+// of the guest nationality market, or the default one.
+func (h *HotelBeds) account(cfg client.SupplierConfig, market string) (*account, error) {
+	if cfg.Credentials != nil {
+		return &account{name: RequestKey, keys: []Key{{
+			Name:   RequestKey,
+			ApiKey: cfg.Credentials.ApiKey,
+			Secret: cfg.Credentials.Secret,
+		}}}, nil
+	}
+
+	if cfg.Account != "" {
+		if cfg.Account == DefaultAccount {
+			return h.defaultAcc, nil
+		}
+
+		acc, ok := h.accounts.lookup(cfg.Account)
+		if !ok {
+			return nil, liteapierrors.NewInvalidRequestErr(ErrCodeUnknownAccount,
+				fmt.Sprintf("no Hotelbeds account is called %s", cfg.Account))
+		}
+
+		return acc, nil
+	}
+
+	if acc, ok := h.accounts.forNationality(market); ok {
+		return acc, nil
+	}
+
+	return h.defaultAcc, nil
+}
+
+// refused reports whether Hotelbeds refused the key a request was signed with.
+func refused(err error) bool {
+	var apiErr *liteapierrors.APIErr
+	return errors.As(err, &apiErr) && apiErr.Kind() == liteapierrors.KindSupplierAuth
+}
+
+// send sends a request to reqURL signed with key, with payload as JSON body when not nil, and decodes the response
+// into out.
+func (h *HotelBeds) send(ctx context.Context, method, reqURL string, timeout time.Duration, key Key, payload []byte,
+	out any) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add(headerApiKey, key.ApiKey)
 	req.Header.Add(headerAccept, applicationJSON)
 	req.Header.Add(headerAcceptEncoding, gzipEncoding)
-	req.Header.Add(headerContentType, applicationJSON)
+	if payload != nil {
+		req.Header.Add(headerContentType, applicationJSON)
+	}
 
-	signature := h.sign()
+	// The timeout of the operation bounds the wait for the rate limit too, a request which cannot be sent in time
+	// fails with ErrCodeRateLimitExceeded rather than queueing past it.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	if err := h.acquire(ctx); err != nil {
+		return err
+	}
+
+	signature := h.sign(key)
 	req.Header.Add(headerXSignature, signature)
 
 	resp, err := h.cli.Do(req)
 	if err != nil {
-		return client.SearchResponse{}, err
+		return err
 	}
 
 	defer func() {
@@ -85,33 +334,24 @@ func (h *HotelBeds) Search(ctx context.Context, searchReq client.SearchRequest)
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return client.SearchResponse{}, h.handleErrors(resp)
+		return h.handleErrors(resp)
 	}
 
-	var (
-		searchResp client.SearchResponse
-		reader     io.Reader = resp.Body
-	)
-
+	var reader io.Reader = resp.Body
 	if resp.Header.Get(headerContentEncoding) == gzipEncoding {
 		reader, err = gzip.NewReader(resp.Body)
 		if err != nil {
-			return client.SearchResponse{}, err
+			return err
 		}
 	}
 
 	decoder := json.NewDecoder(reader)
-	err = decoder.Decode(&searchResp)
-	if err != nil {
-		return client.SearchResponse{}, err
-	}
-
-	return searchResp, nil
+	return decoder.Decode(out)
 }
 
-func (h *HotelBeds) sign() string {
+func (h *HotelBeds) sign(key Key) string {
 	// Begin Signature Assembly
-	assemble := fmt.Sprintf("%s%s%d", h.apiKey, h.secret, h.clock.Now().Unix())
+	assemble := fmt.Sprintf("%s%s%d", key.ApiKey, key.Secret, h.clock.Now().Unix())
 
 	// Begin SHA-256 Encryption
 	hash := sha256.New()
@@ -129,19 +369,21 @@ func (h *HotelBeds) handleErrors(resp *http.Response) error {
 			return fmt.Errorf("error decoding error message: %w", err)
 		}
 
-		return liteapierrors.NewAPIErr(http.StatusText(resp.StatusCode), simpleErr.Error)
+		return liteapierrors.NewUpstreamErr(resp.StatusCode, http.StatusText(resp.StatusCode), simpleErr.Error).
+			WithRetryAfter(h.retryAfter(resp))
 	case http.StatusPaymentRequired, http.StatusNotAcceptable, http.StatusConflict, http.StatusGone,
 		http.StatusUnsupportedMediaType, http.StatusBadGateway, http.StatusServiceUnavailable,
 		http.StatusGatewayTimeout:
 
-		return liteapierrors.NewAPIErr(http.StatusText(resp.StatusCode), "internal server error")
+		return liteapierrors.NewUpstreamErr(resp.StatusCode, http.StatusText(resp.StatusCode), "internal server error").
+			WithRetryAfter(h.retryAfter(resp))
 	default:
-		searchResp := client.SearchResponse{}
-		err := decoder.Decode(&searchResp)
+		errResp := client.ErrorResponse{}
+		err := decoder.Decode(&errResp)
 		if err != nil {
 			return fmt.Errorf("error decoding error message: %w", err)
 		}
 
-		return liteapierrors.NewAPIErr(searchResp.Error.Code, searchResp.Error.Message)
+		return liteapierrors.NewUpstreamErr(resp.StatusCode, errResp.Error.Code, errResp.Error.Message)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"lite-api/internal/client"
 	liteapierrors "lite-api/internal/errors"
 	"net/http"
@@ -22,26 +23,35 @@ import (
 //go:embed testdata/hotelbeds_response.json
 var hotelbedsResponse []byte
 
+//go:embed testdata/hotelbeds_checkrate_response.json
+var hotelbedsCheckRateResponse []byte
+
+//go:embed testdata/hotelbeds_booking_response.json
+var hotelbedsBookingResponse []byte
+
 func TestHotelBeds_Search(t *testing.T) {
 	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
 	apiKey, secret := "12345", "6789"
 
 	t.Run("client error", func(t *testing.T) {
-		hotelBedsCli := NewHotelBeds("0.0.0.0", apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds("0.0.0.0", apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.Error(t, err)
 		require.Zero(t, res)
 	})
 
 	t.Run("invalid request", func(t *testing.T) {
-		hotelBedsCli := NewHotelBeds("http://///invalid-url", apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds("http://///invalid-url", apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.Error(t, err)
 		require.Zero(t, res)
 	})
 
 	t.Run("invalid request", func(t *testing.T) {
-		hotelBedsCli := NewHotelBeds("http://///invalid-url", apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds("http://///invalid-url", apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.Error(t, err)
 		require.Zero(t, res)
@@ -65,7 +75,8 @@ func TestHotelBeds_Search(t *testing.T) {
 			_, _ = fmt.Fprintln(w, `{"message": "hello, world"}`)
 		}))
 		defer mockServer.Close()
-		hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.NoError(t, err)
 		require.Zero(t, res)
@@ -93,12 +104,113 @@ func TestHotelBeds_Search(t *testing.T) {
 			_, _ = fmt.Fprintln(w, `{"message": "hello, world"}`)
 		}))
 		defer mockServer.Close()
-		hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.NoError(t, err)
 		require.Zero(t, res)
 	})
 
+	t.Run("source market in request body", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, "GB", body["sourceMarket"])
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{SourceMarket: "GB"})
+		require.NoError(t, err)
+	})
+
+	t.Run("children paxes in request body", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{
+				"stay": {"checkIn": "", "checkOut": ""},
+				"occupancies": [{
+					"rooms": 1, "adults": 2, "children": 2,
+					"paxes": [{"type": "CH", "age": 0}, {"type": "CH", "age": 7}]
+				}]
+			}`, string(body))
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{
+			Occupancies: client.Occupancies{{
+				Rooms:    1,
+				Adults:   2,
+				Children: 2,
+				Paxes:    client.Paxes{{Type: client.PaxChild, Age: 0}, {Type: client.PaxChild, Age: 7}},
+			}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("destination in request body", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{
+				"stay": {"checkIn": "", "checkOut": ""},
+				"occupancies": null,
+				"destination": {"code": "PMI", "zone": 20}
+			}`, string(body))
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{
+			Destination: &client.Destination{Code: "PMI", Zone: 20},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("geolocation in request body", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{
+				"stay": {"checkIn": "", "checkOut": ""},
+				"occupancies": null,
+				"geolocation": {"latitude": 39.57, "longitude": 2.65, "radius": 5, "unit": "km"}
+			}`, string(body))
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{
+			Geolocation: &client.Geolocation{Latitude: 39.57, Longitude: 2.65, Radius: 5, Unit: client.UnitKilometers},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("source market omitted when empty", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.NotContains(t, body, "sourceMarket")
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+	})
+
 	t.Run("error status codes", func(t *testing.T) {
 		t.Run("no body", func(t *testing.T) {
 			noBodyStatusCodes := []int{
@@ -118,10 +230,14 @@ func TestHotelBeds_Search(t *testing.T) {
 				}))
 				defer mockServer.Close()
 
-				hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+				hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+				require.NoError(t, err)
 				res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 				expectedErr := liteapierrors.NewAPIErr(http.StatusText(statusCode), "internal server error")
 				require.ErrorContains(t, err, expectedErr.Error())
+				var apiErr *liteapierrors.APIErr
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, statusCode, apiErr.Status())
 				require.Zero(t, res)
 			}
 
@@ -146,10 +262,14 @@ func TestHotelBeds_Search(t *testing.T) {
 				}))
 				defer mockServer.Close()
 
-				hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+				hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+				require.NoError(t, err)
 				res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 				expectedErr := liteapierrors.NewAPIErr(http.StatusText(statusCode), "something went wrong")
 				require.ErrorContains(t, err, expectedErr.Error())
+				var apiErr *liteapierrors.APIErr
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, statusCode, apiErr.Status())
 				require.Zero(t, res)
 			}
 
@@ -181,10 +301,14 @@ func TestHotelBeds_Search(t *testing.T) {
 				}))
 				defer mockServer.Close()
 
-				hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+				hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+				require.NoError(t, err)
 				res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 				expectedErr := liteapierrors.NewAPIErr("ERR CODE", "detailed message")
 				require.ErrorContains(t, err, expectedErr.Error())
+				var apiErr *liteapierrors.APIErr
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, statusCode, apiErr.Status())
 				require.Zero(t, res)
 			}
 
@@ -204,7 +328,8 @@ func TestHotelBeds_Search(t *testing.T) {
 			_, _ = fmt.Fprintln(w, `{`)
 		}))
 		defer mockServer.Close()
-		hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.Error(t, err)
 		require.Zero(t, res)
@@ -231,7 +356,8 @@ func TestHotelBeds_Search(t *testing.T) {
 
 		defer mockServer.Close()
 
-		hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.NoError(t, err)
 		require.Equal(t, upstreamResp, res)
@@ -261,9 +387,384 @@ func TestHotelBeds_Search(t *testing.T) {
 
 		defer mockServer.Close()
 
-		hotelBedsCli := NewHotelBeds(mockServer.URL, apiKey, secret, staticClock, nil)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
 		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
 		require.NoError(t, err)
 		require.Equal(t, upstreamResp, res)
 	})
 }
+
+func TestHotelBeds_CheckRate(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	apiKey, secret := "12345", "6789"
+	checkRateReq := client.CheckRateRequest{
+		Rooms: client.CheckRateRooms{{RateKey: "some-rate-key"}},
+	}
+
+	t.Run("client error", func(t *testing.T) {
+		hotelBedsCli, err := NewHotelBeds("0.0.0.0", apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.CheckRate(context.Background(), checkRateReq)
+		require.Error(t, err)
+		require.Zero(t, res)
+	})
+
+	t.Run("error status code", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"auditData": {}, "error": {"code": "INVALID_DATA", "message": "rate key expired"}}`))
+		}))
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.CheckRate(context.Background(), checkRateReq)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindInvalidRequest, apiErr.Kind())
+		require.Equal(t, "INVALID_DATA", apiErr.Code())
+		require.Zero(t, res)
+	})
+
+	t.Run("client success", func(t *testing.T) {
+		var upstreamResp client.CheckRateResponse
+		require.NoError(t, json.Unmarshal(hotelbedsCheckRateResponse, &upstreamResp))
+
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, checkRatesEndpoint, r.URL.Path)
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, apiKey, r.Header.Get(headerApiKey))
+			require.NotEmpty(t, r.Header.Get(headerXSignature))
+
+			var gotReq client.CheckRateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+			require.Equal(t, checkRateReq, gotReq)
+
+			_, _ = w.Write(hotelbedsCheckRateResponse)
+		}))
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.CheckRate(context.Background(), checkRateReq)
+		require.NoError(t, err)
+		require.Equal(t, upstreamResp, res)
+		require.Equal(t, "384.25", res.Hotel.TotalNet)
+		require.NotEmpty(t, res.Hotel.Rooms[0].Rates[0].RateComments)
+	})
+}
+
+func TestHotelBeds_Bookings(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	apiKey, secret := "12345", "6789"
+	reference := "102-4256498"
+
+	var upstreamResp client.BookingResponse
+	require.NoError(t, json.Unmarshal(hotelbedsBookingResponse, &upstreamResp))
+
+	t.Run("book", func(t *testing.T) {
+		bookingReq := client.BookingRequest{
+			Holder: client.Holder{Name: "Jane", Surname: "Doe"},
+			Rooms: client.BookingRooms{
+				{
+					RateKey: "some-rate-key",
+					Paxes:   client.Paxes{{RoomID: 1, Type: client.PaxAdult, Name: "Jane", Surname: "Doe"}},
+				},
+			},
+			ClientReference: "LITEAPI-0001",
+		}
+
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, bookingsEndpoint, r.URL.Path)
+			require.Equal(t, http.MethodPost, r.Method)
+			require.NotEmpty(t, r.Header.Get(headerXSignature))
+
+			var gotReq client.BookingRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+			require.Equal(t, bookingReq, gotReq)
+
+			_, _ = w.Write(hotelbedsBookingResponse)
+		}))
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.Book(context.Background(), bookingReq)
+		require.NoError(t, err)
+		require.Equal(t, upstreamResp, res)
+	})
+
+	t.Run("booking detail", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, bookingsEndpoint+"/"+reference, r.URL.Path)
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Empty(t, r.Header.Get(headerContentType))
+
+			_, _ = w.Write(hotelbedsBookingResponse)
+		}))
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.BookingDetail(context.Background(), reference)
+		require.NoError(t, err)
+		require.Equal(t, upstreamResp, res)
+	})
+
+	t.Run("booking detail not found", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"auditData": {}, "error": {"code": "PRODUCT_ERROR", "message": "booking not found"}}`))
+		}))
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+		res, err := hotelBedsCli.BookingDetail(context.Background(), reference)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindNotFound, apiErr.Kind())
+		require.Zero(t, res)
+	})
+
+	t.Run("cancel booking", func(t *testing.T) {
+		for _, flag := range []client.CancellationFlag{client.CancellationSimulation, client.CancellationConfirm} {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, bookingsEndpoint+"/"+reference, r.URL.Path)
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(t, string(flag), r.URL.Query().Get(cancellationFlagParam))
+
+				_, _ = w.Write(hotelbedsBookingResponse)
+			}))
+
+			hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+			require.NoError(t, err)
+			res, err := hotelBedsCli.CancelBooking(context.Background(), reference, flag)
+			require.NoError(t, err)
+			require.Equal(t, upstreamResp, res)
+			mockServer.Close()
+		}
+	})
+}
+
+func TestHotelBeds_SupplierConfig(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	apiKey, secret := "12345", "6789"
+
+	t.Run("environment host and credentials", func(t *testing.T) {
+		var defaultCalls int
+		defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defaultCalls++
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer defaultServer.Close()
+
+		liveServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hash := sha256.Sum256([]byte(fmt.Sprintf("%s%s%d", "other-key", "other-secret", staticClock.Now().Unix())))
+			require.Equal(t, "other-key", r.Header.Get(headerApiKey))
+			require.Equal(t, hex.EncodeToString(hash[:]), r.Header.Get(headerXSignature))
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer liveServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(defaultServer.URL, apiKey, secret,
+			Options{Environments: map[string]string{"live": liveServer.URL + "/"}}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{
+			Environment: "live",
+			Credentials: &client.Credentials{ApiKey: "other-key", Secret: "other-secret"},
+		})
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+		require.NoError(t, err)
+		require.Zero(t, defaultCalls)
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		hotelBedsCli, err := NewHotelBeds("0.0.0.0", apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "live"})
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeUnknownEnvironment, apiErr.Code())
+		require.Equal(t, liteapierrors.KindInvalidRequest, apiErr.Kind())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer mockServer.Close()
+		defer close(release)
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, apiKey, secret, Options{}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Timeout: 10 * time.Millisecond})
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestHotelBeds_Accounts(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	accounts, err := NewAccounts([]Account{
+		{
+			Name: "eu",
+			Keys: []Key{
+				{Name: "2026-q3", ApiKey: "old-key", Secret: "old-secret"},
+				{Name: "2026-q4", ApiKey: "new-key", Secret: "new-secret"},
+			},
+			Nationalities: []string{"ES"},
+		},
+		{Name: "us", Keys: []Key{{Name: "2026-q4", ApiKey: "us-key", Secret: "us-secret"}}},
+	})
+	require.NoError(t, err)
+
+	// newServer returns a server refusing the API keys of revoked and recording the API keys of the requests.
+	newServer := func(revoked string, apiKeys *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := r.Header.Get(headerApiKey)
+			*apiKeys = append(*apiKeys, apiKey)
+			if apiKey == revoked {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = fmt.Fprintln(w, `{"error": "Request signature verification failed"}`)
+				return
+			}
+
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+	}
+
+	t.Run("account of the guest nationality", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("", &apiKeys)
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: accounts}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx, rec := client.WithKeyRecorder(context.Background())
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{SourceMarket: "ES"})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{SourceMarket: "US"})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"old-key", "default-key"}, apiKeys)
+		require.Equal(t, []string{"eu/2026-q3", "default/default"}, rec.Keys())
+	})
+
+	t.Run("account of the market of a rate check or booking", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("", &apiKeys)
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: accounts}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithMarket(context.Background(), "ES")
+		_, err = hotelBedsCli.CheckRate(ctx, client.CheckRateRequest{})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Book(ctx, client.BookingRequest{})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.CancelBooking(ctx, "1-3087550", client.CancellationConfirm)
+		require.NoError(t, err)
+		_, err = hotelBedsCli.CheckRate(context.Background(), client.CheckRateRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"old-key", "old-key", "old-key", "default-key"}, apiKeys)
+	})
+
+	t.Run("account named by the supplier config", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("", &apiKeys)
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: accounts}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Account: "us"})
+		_, err = hotelBedsCli.BookingDetail(ctx, "1-3087550")
+		require.NoError(t, err)
+		require.Equal(t, []string{"us-key"}, apiKeys)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		hotelBedsCli, err := NewHotelBeds("0.0.0.0", "default-key", "default-secret",
+			Options{Accounts: accounts}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Account: "asia"})
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeUnknownAccount, apiErr.Code())
+	})
+
+	t.Run("refused key falls back to the next one, which is then tried first", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("old-key", &apiKeys)
+		defer mockServer.Close()
+
+		rotating, err := NewAccounts([]Account{{Name: "eu", Keys: accounts.accounts["eu"].keys}})
+		require.NoError(t, err)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: rotating}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx, rec := client.WithKeyRecorder(client.WithSupplierConfig(context.Background(),
+			client.SupplierConfig{Account: "eu"}))
+		_, err = hotelBedsCli.CheckRate(ctx, client.CheckRateRequest{})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.CheckRate(ctx, client.CheckRateRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"old-key", "new-key", "new-key"}, apiKeys)
+		require.Equal(t, []string{"eu/2026-q4"}, rec.Keys())
+	})
+
+	t.Run("every key refused", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("us-key", &apiKeys)
+		defer mockServer.Close()
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: accounts}, staticClock, nil)
+		require.NoError(t, err)
+
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Account: "us"})
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, liteapierrors.KindSupplierAuth, apiErr.Kind())
+		require.Equal(t, []string{"us-key"}, apiKeys)
+	})
+
+	t.Run("default account of the accounts", func(t *testing.T) {
+		var apiKeys []string
+		mockServer := newServer("", &apiKeys)
+		defer mockServer.Close()
+
+		withDefault, err := NewAccounts([]Account{
+			{Name: DefaultAccount, Keys: []Key{{Name: "2026-q4", ApiKey: "new-key", Secret: "new-secret"}}},
+		})
+		require.NoError(t, err)
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "default-key", "default-secret",
+			Options{Accounts: withDefault}, staticClock, nil)
+		require.NoError(t, err)
+
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"new-key"}, apiKeys)
+	})
+}
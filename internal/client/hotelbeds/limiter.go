@@ -0,0 +1,161 @@
+package hotelbeds
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	liteapierrors "lite-api/internal/errors"
+	"sync"
+	"time"
+)
+
+const (
+	// ErrCodeRateLimitExceeded is the code of a request which could not be sent before its deadline
+	// without exceeding the per second quota.
+	ErrCodeRateLimitExceeded = "rate_limit_exceeded"
+	// ErrCodeDailyQuotaExceeded is the code of a request rejected because the daily quota is used up.
+	ErrCodeDailyQuotaExceeded = "daily_quota_exceeded"
+
+	quotaDayLayout = time.DateOnly
+)
+
+// RateLimit configures the client-side quota of Hotelbeds requests, every request counts including retries.
+type RateLimit struct {
+	// PerSecond is the sustained number of requests per second, zero disables the limit.
+	PerSecond float64
+	// Burst is the number of requests which may be sent at once after an idle period.
+	Burst int
+	// PerDay is the number of requests per UTC day, zero disables the limit.
+	PerDay int
+}
+
+// QuotaUsage reports the requests sent to Hotelbeds during the current UTC day.
+type QuotaUsage struct {
+	Day   string `json:"day"`
+	Used  int    `json:"used"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// limiter is a token bucket refilled at rate tokens per second up to burst tokens, combined with a daily counter.
+// Tokens may go negative, which queues the request until the bucket has refilled.
+type limiter struct {
+	mu     sync.Mutex
+	cfg    RateLimit
+	tokens float64
+	last   time.Time
+	day    string
+	used   int
+}
+
+func newLimiter(cfg RateLimit) *limiter {
+	cfg.Burst = max(cfg.Burst, 1)
+
+	return &limiter{
+		cfg:    cfg,
+		tokens: float64(cfg.Burst),
+	}
+}
+
+// reserve takes a token at now and returns how long to wait before sending the request.
+// It fails without taking a token if the daily quota is used up or the wait would outlast deadline.
+func (l *limiter) reserve(now, deadline time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if day := now.UTC().Format(quotaDayLayout); day != l.day {
+		l.day, l.used = day, 0
+	}
+
+	if l.cfg.PerDay > 0 && l.used >= l.cfg.PerDay {
+		year, month, day := now.UTC().Date()
+		midnight := time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+
+		return 0, liteapierrors.NewQuotaErr(ErrCodeDailyQuotaExceeded,
+			fmt.Sprintf("daily quota of %d hotelbeds requests is used up", l.cfg.PerDay), midnight.Sub(now))
+	}
+
+	var wait time.Duration
+	if l.cfg.PerSecond > 0 {
+		if !l.last.IsZero() {
+			l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.cfg.PerSecond, float64(l.cfg.Burst))
+		}
+		l.last = now
+
+		if l.tokens < 1 {
+			wait = time.Duration((1 - l.tokens) / l.cfg.PerSecond * float64(time.Second))
+		}
+
+		if !deadline.IsZero() && now.Add(wait).After(deadline) {
+			return 0, liteapierrors.NewQuotaErr(ErrCodeRateLimitExceeded,
+				fmt.Sprintf("hotelbeds rate limit of %g requests per second exceeded", l.cfg.PerSecond), wait)
+		}
+
+		l.tokens--
+	}
+
+	l.used++
+	return wait, nil
+}
+
+// cancel gives back the token taken by a reservation whose request was never sent.
+func (l *limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg.PerSecond > 0 {
+		l.tokens++
+	}
+	l.used = max(l.used-1, 0)
+}
+
+func (l *limiter) usage(now time.Time) QuotaUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage := QuotaUsage{
+		Day:   now.UTC().Format(quotaDayLayout),
+		Limit: l.cfg.PerDay,
+	}
+	if usage.Day == l.day {
+		usage.Used = l.used
+	}
+
+	return usage
+}
+
+// acquire waits until a request may be sent to Hotelbeds without exceeding the quota of the host of the environment
+// of the client.SupplierConfig of ctx.
+func (h *HotelBeds) acquire(ctx context.Context) error {
+	host := h.host
+	if environment := client.SupplierConfigFrom(ctx).Environment; environment != "" {
+		host = h.hosts[environment]
+	}
+	l := h.limiters[host]
+
+	// The deadline of ctx is on the wall clock, the limiter measures time with h.clock.
+	now := h.clock.Now()
+	var deadline time.Time
+	if d, ok := ctx.Deadline(); ok {
+		deadline = now.Add(time.Until(d))
+	}
+
+	wait, err := l.reserve(now, deadline)
+	if err != nil {
+		return err
+	}
+
+	if wait > 0 {
+		if err := h.sleep(ctx, wait); err != nil {
+			l.cancel()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QuotaUsage returns the requests sent to the Hotelbeds host during the current UTC day, including the ones of the
+// environments sending requests to it.
+func (h *HotelBeds) QuotaUsage() QuotaUsage {
+	return h.limiters[h.host].usage(h.clock.Now())
+}
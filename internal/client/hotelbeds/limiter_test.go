@@ -0,0 +1,234 @@
+package hotelbeds
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	liteapierrors "lite-api/internal/errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a clock.Clock which only moves when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+func (m *manualClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	now := time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := newLimiter(RateLimit{})
+		for range 100 {
+			wait, err := l.reserve(now, time.Time{})
+			require.NoError(t, err)
+			require.Zero(t, wait)
+		}
+		require.Equal(t, QuotaUsage{Day: "2024-07-12", Used: 100}, l.usage(now))
+	})
+
+	t.Run("queues beyond burst", func(t *testing.T) {
+		l := newLimiter(RateLimit{PerSecond: 2, Burst: 2})
+		for _, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+			wait, err := l.reserve(now, time.Time{})
+			require.NoError(t, err)
+			require.Equal(t, want, wait)
+		}
+	})
+
+	t.Run("refills over time", func(t *testing.T) {
+		l := newLimiter(RateLimit{PerSecond: 2, Burst: 1})
+		wait, err := l.reserve(now, time.Time{})
+		require.NoError(t, err)
+		require.Zero(t, wait)
+
+		wait, err = l.reserve(now.Add(250*time.Millisecond), time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, 250*time.Millisecond, wait)
+
+		wait, err = l.reserve(now.Add(10*time.Second), time.Time{})
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	})
+
+	t.Run("rejects when the wait outlasts the deadline", func(t *testing.T) {
+		l := newLimiter(RateLimit{PerSecond: 1, Burst: 1})
+		_, err := l.reserve(now, time.Time{})
+		require.NoError(t, err)
+
+		_, err = l.reserve(now, now.Add(500*time.Millisecond))
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeRateLimitExceeded, apiErr.Code())
+		require.Equal(t, liteapierrors.KindRateLimited, apiErr.Kind())
+		require.Equal(t, time.Second, apiErr.RetryAfter())
+
+		wait, err := l.reserve(now, now.Add(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, time.Second, wait)
+	})
+
+	t.Run("daily quota", func(t *testing.T) {
+		l := newLimiter(RateLimit{PerDay: 2})
+		for range 2 {
+			_, err := l.reserve(now, time.Time{})
+			require.NoError(t, err)
+		}
+
+		_, err := l.reserve(now, time.Time{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeDailyQuotaExceeded, apiErr.Code())
+		require.Equal(t, 12*time.Hour+55*time.Minute+55*time.Second, apiErr.RetryAfter())
+		require.Equal(t, QuotaUsage{Day: "2024-07-12", Used: 2, Limit: 2}, l.usage(now))
+
+		tomorrow := now.Add(24 * time.Hour)
+		require.Equal(t, QuotaUsage{Day: "2024-07-13", Limit: 2}, l.usage(tomorrow))
+		_, err = l.reserve(tomorrow, time.Time{})
+		require.NoError(t, err)
+	})
+
+	t.Run("cancel gives the token back", func(t *testing.T) {
+		l := newLimiter(RateLimit{PerSecond: 1, Burst: 1, PerDay: 1})
+		_, err := l.reserve(now, time.Time{})
+		require.NoError(t, err)
+		l.cancel()
+
+		wait, err := l.reserve(now, time.Time{})
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	})
+}
+
+func TestHotelBeds_RateLimit(t *testing.T) {
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer mockServer.Close()
+
+	clk := &manualClock{now: time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)}
+	hotelBedsCli, err := NewHotelBeds(mockServer.URL, "12345", "6789", Options{
+		RateLimit: RateLimit{PerSecond: 1, Burst: 1, PerDay: 3},
+	}, clk, nil)
+	require.NoError(t, err)
+	var waits []time.Duration
+	hotelBedsCli.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		clk.Advance(d)
+		return nil
+	}
+
+	_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+	require.NoError(t, err)
+	_, err = hotelBedsCli.CheckRate(context.Background(), client.CheckRateRequest{})
+	require.NoError(t, err)
+	_, err = hotelBedsCli.BookingDetail(context.Background(), "1-1")
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{time.Second, time.Second}, waits)
+
+	_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+	var apiErr *liteapierrors.APIErr
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, ErrCodeDailyQuotaExceeded, apiErr.Code())
+	require.Equal(t, 3, calls)
+	require.Equal(t, QuotaUsage{Day: "2024-07-12", Used: 3, Limit: 3}, hotelBedsCli.QuotaUsage())
+
+	t.Run("cancelled while queued", func(t *testing.T) {
+		clk.Advance(24 * time.Hour)
+		hotelBedsCli.sleep = func(ctx context.Context, d time.Duration) error {
+			return context.Canceled
+		}
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 4, calls)
+		require.Equal(t, QuotaUsage{Day: "2024-07-13", Used: 1, Limit: 3}, hotelBedsCli.QuotaUsage())
+	})
+}
+
+func TestHotelBeds_RateLimitTimeout(t *testing.T) {
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer mockServer.Close()
+
+	clk := &manualClock{now: time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)}
+	hotelBedsCli, err := NewHotelBeds(mockServer.URL, "12345", "6789", Options{
+		RateLimit: RateLimit{PerSecond: 1, Burst: 1},
+		Timeouts:  Timeouts{Search: 500 * time.Millisecond},
+	}, clk, nil)
+	require.NoError(t, err)
+	hotelBedsCli.sleep = func(ctx context.Context, d time.Duration) error {
+		t.Fatalf("queued for %s past the search timeout", d)
+		return nil
+	}
+
+	_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+	require.NoError(t, err)
+
+	// The next token is a second away, beyond the timeout of the search.
+	_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+	var apiErr *liteapierrors.APIErr
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, ErrCodeRateLimitExceeded, apiErr.Code())
+	require.Equal(t, 1, calls)
+}
+
+func TestHotelBeds_EnvironmentRateLimit(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `{}`)
+	})
+	liveServer := httptest.NewServer(handler)
+	defer liveServer.Close()
+	testServer := httptest.NewServer(handler)
+	defer testServer.Close()
+
+	clk := &manualClock{now: time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC)}
+	hotelBedsCli, err := NewHotelBeds(liveServer.URL, "12345", "6789", Options{
+		RateLimit:    RateLimit{PerDay: 1},
+		Environments: map[string]string{"test": testServer.URL, "live": liveServer.URL + "/"},
+	}, clk, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"test"}, hotelBedsCli.ForeignEnvironments())
+
+	testCtx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "test"})
+	liveCtx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Environment: "live"})
+	_, err = hotelBedsCli.Search(testCtx, client.SearchRequest{})
+	require.NoError(t, err)
+	_, err = hotelBedsCli.Search(liveCtx, client.SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, QuotaUsage{Day: "2024-07-12", Used: 1, Limit: 1}, hotelBedsCli.QuotaUsage())
+
+	for _, ctx := range []context.Context{testCtx, liveCtx, context.Background()} {
+		_, err = hotelBedsCli.Search(ctx, client.SearchRequest{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeDailyQuotaExceeded, apiErr.Code())
+	}
+}
@@ -0,0 +1,156 @@
+package hotelbeds
+
+import (
+	"context"
+	"errors"
+	"io"
+	liteapierrors "lite-api/internal/errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxRetryTokens caps the retry budget so that a long healthy period cannot save up a retry storm.
+const maxRetryTokens = 10
+
+// RetryPolicy configures how failed idempotent availability requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled for every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than MaxDelay is not waited for and the error is returned.
+	MaxDelay time.Duration
+	// Jitter is the fraction of the backoff which is randomised, between 0 and 1.
+	Jitter float64
+	// BudgetRatio is the number of retries earned by every request, e.g. 0.1 allows one retry every ten requests.
+	// Zero disables the budget.
+	BudgetRatio float64
+}
+
+// retryBudget caps retries to a ratio of the requests made, so that retries cannot multiply the load on
+// Hotelbeds during an outage. Every request deposits ratio tokens and every retry withdraws one.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newRetryBudget(ratio float64) *retryBudget {
+	return &retryBudget{
+		ratio:  ratio,
+		tokens: maxRetryTokens,
+	}
+}
+
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, maxRetryTokens)
+}
+
+func (b *retryBudget) withdraw() bool {
+	if b.ratio == 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// retry calls attempt until it succeeds, fails with a non-retryable error, runs out of attempts or retry budget,
+// or ctx is done. The last error is returned.
+func (h *HotelBeds) retry(ctx context.Context, attempt func() error) error {
+	h.budget.deposit()
+
+	for i := 1; ; i++ {
+		err := attempt()
+		if err == nil || i >= h.retryPolicy.MaxAttempts || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		delay, ok := h.backoff(i, err)
+		if !ok || !h.budget.withdraw() {
+			return err
+		}
+
+		if h.sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// backoff returns the delay before the given retry, honoring Retry-After when Hotelbeds sent it.
+// It returns false when Hotelbeds asked to wait longer than the policy allows.
+func (h *HotelBeds) backoff(retry int, err error) (time.Duration, bool) {
+	var apiErr *liteapierrors.APIErr
+	if errors.As(err, &apiErr) && apiErr.RetryAfter() > 0 {
+		return apiErr.RetryAfter(), h.retryPolicy.MaxDelay == 0 || apiErr.RetryAfter() <= h.retryPolicy.MaxDelay
+	}
+
+	delay := h.retryPolicy.BaseDelay << (retry - 1)
+	if h.retryPolicy.MaxDelay > 0 && (delay > h.retryPolicy.MaxDelay || delay <= 0) {
+		delay = h.retryPolicy.MaxDelay
+	}
+
+	jitter := time.Duration(h.retryPolicy.Jitter * h.random() * float64(delay))
+	return delay - jitter, true
+}
+
+// retryAfter parses the Retry-After header which is either a number of seconds or an HTTP date.
+func (h *HotelBeds) retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get(headerRetryAfter)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(h.clock.Now()), 0)
+	}
+
+	return 0
+}
+
+// retryable reports whether err is a transient failure which may succeed when retried.
+// The quota errors of the limiter are not, their Retry-After may be as far as the next UTC day.
+func retryable(err error) bool {
+	var apiErr *liteapierrors.APIErr
+	if errors.As(err, &apiErr) {
+		return apiErr.Status() != 0 && apiErr.Retryable()
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,260 @@
+package hotelbeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"lite-api/internal/client"
+	liteapierrors "lite-api/internal/errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+)
+
+// newRetryingHotelBeds returns a client against url which records its backoffs instead of sleeping.
+func newRetryingHotelBeds(t *testing.T, url string, policy RetryPolicy, clock clock.Clock) (*HotelBeds, *[]time.Duration) {
+	t.Helper()
+
+	var delays []time.Duration
+	hotelBedsCli, err := NewHotelBeds(url, "12345", "6789", Options{Retry: policy}, clock, nil)
+	require.NoError(t, err)
+	hotelBedsCli.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	hotelBedsCli.random = func() float64 { return 0.5 }
+
+	return hotelBedsCli, &delays
+}
+
+// failingServer answers the first failures requests with status and headers, then with a search response.
+func failingServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintln(w, `{"error": "try again"}`)
+			return
+		}
+
+		_, _ = w.Write(hotelbedsResponse)
+	}))
+	t.Cleanup(mockServer.Close)
+
+	return mockServer, &calls
+}
+
+func TestHotelBeds_SearchRetry(t *testing.T) {
+	staticClock := clock.Fix(time.Date(2024, 7, 12, 11, 4, 5, 0, time.UTC))
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}
+
+	t.Run("retries unavailable with exponential backoff", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 2, http.StatusServiceUnavailable, nil)
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.NotZero(t, res)
+		require.EqualValues(t, 3, calls.Load())
+		require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *delays)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 5, http.StatusGatewayTimeout, nil)
+		hotelBedsCli, _ := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		res, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.Zero(t, res)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusGatewayTimeout, apiErr.Status())
+		require.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("does not retry non retryable errors", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 1, http.StatusUnauthorized, nil)
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.Error(t, err)
+		require.EqualValues(t, 1, calls.Load())
+		require.Empty(t, *delays)
+	})
+
+	t.Run("does not retry exceeded local quotas", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 0, http.StatusOK, nil)
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, RetryPolicy{MaxAttempts: 3}, staticClock)
+		hotelBedsCli.limiters[hotelBedsCli.host] = newLimiter(RateLimit{PerDay: 1})
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeDailyQuotaExceeded, apiErr.Code())
+		require.EqualValues(t, 1, calls.Load())
+		require.Empty(t, *delays)
+	})
+
+	t.Run("applies jitter", func(t *testing.T) {
+		mockServer, _ := failingServer(t, 2, http.StatusBadGateway, nil)
+		jittered := policy
+		jittered.Jitter = 0.5
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, jittered, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{75 * time.Millisecond, 150 * time.Millisecond}, *delays)
+	})
+
+	t.Run("caps backoff at max delay", func(t *testing.T) {
+		mockServer, _ := failingServer(t, 3, http.StatusBadGateway, nil)
+		capped := policy
+		capped.MaxAttempts = 4
+		capped.BaseDelay = 400 * time.Millisecond
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, capped, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{400 * time.Millisecond, 800 * time.Millisecond, time.Second}, *delays)
+	})
+
+	t.Run("honors retry after seconds", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 1, http.StatusTooManyRequests, http.Header{headerRetryAfter: {"1"}})
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, calls.Load())
+		require.Equal(t, []time.Duration{time.Second}, *delays)
+	})
+
+	t.Run("honors retry after date", func(t *testing.T) {
+		retryAt := staticClock.Now().Add(time.Second).UTC().Format(http.TimeFormat)
+		mockServer, _ := failingServer(t, 1, http.StatusTooManyRequests, http.Header{headerRetryAfter: {retryAt}})
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{time.Second}, *delays)
+	})
+
+	t.Run("does not wait for retry after beyond max delay", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 1, http.StatusTooManyRequests, http.Header{headerRetryAfter: {"30"}})
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, 30*time.Second, apiErr.RetryAfter())
+		require.EqualValues(t, 1, calls.Load())
+		require.Empty(t, *delays)
+	})
+
+	t.Run("stops when context is cancelled during backoff", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 5, http.StatusServiceUnavailable, nil)
+		hotelBedsCli, _ := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+		ctx, cancel := context.WithCancel(context.Background())
+		hotelBedsCli.sleep = func(context.Context, time.Duration) error {
+			cancel()
+			return context.Canceled
+		}
+
+		_, err := hotelBedsCli.Search(ctx, client.SearchRequest{})
+		require.Error(t, err)
+		require.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("stops when retry budget is exhausted", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 100, http.StatusServiceUnavailable, nil)
+		budgeted := policy
+		budgeted.MaxAttempts = 100
+		budgeted.BudgetRatio = 0.1
+		hotelBedsCli, delays := newRetryingHotelBeds(t, mockServer.URL, budgeted, staticClock)
+
+		_, err := hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.Error(t, err)
+		require.EqualValues(t, maxRetryTokens+1, calls.Load())
+		require.Len(t, *delays, maxRetryTokens)
+	})
+
+	t.Run("check rate is not retried", func(t *testing.T) {
+		mockServer, calls := failingServer(t, 1, http.StatusServiceUnavailable, nil)
+		hotelBedsCli, _ := newRetryingHotelBeds(t, mockServer.URL, policy, staticClock)
+
+		_, err := hotelBedsCli.CheckRate(context.Background(), client.CheckRateRequest{})
+		require.Error(t, err)
+		require.EqualValues(t, 1, calls.Load())
+	})
+}
+
+func TestRetryBudget(t *testing.T) {
+	t.Run("disabled budget always allows retries", func(t *testing.T) {
+		budget := newRetryBudget(0)
+		for range maxRetryTokens * 2 {
+			require.True(t, budget.withdraw())
+		}
+	})
+
+	t.Run("requests earn retries", func(t *testing.T) {
+		budget := newRetryBudget(0.5)
+		for range maxRetryTokens {
+			require.True(t, budget.withdraw())
+		}
+		require.False(t, budget.withdraw())
+
+		budget.deposit()
+		require.False(t, budget.withdraw())
+		budget.deposit()
+		require.True(t, budget.withdraw())
+	})
+
+	t.Run("tokens are capped", func(t *testing.T) {
+		budget := newRetryBudget(1)
+		for range maxRetryTokens * 2 {
+			budget.deposit()
+		}
+		require.EqualValues(t, maxRetryTokens, budget.tokens)
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unavailable", liteapierrors.NewUpstreamErr(http.StatusServiceUnavailable, "", ""), true},
+		{"rate limited", liteapierrors.NewUpstreamErr(http.StatusTooManyRequests, "", ""), true},
+		{"bad request", liteapierrors.NewUpstreamErr(http.StatusBadRequest, "", ""), false},
+		{"rate limit exceeded", liteapierrors.NewQuotaErr(ErrCodeRateLimitExceeded, "", time.Second), false},
+		{"daily quota exceeded", liteapierrors.NewQuotaErr(ErrCodeDailyQuotaExceeded, "", time.Hour), false},
+		{"connection reset", fmt.Errorf("post: %w", syscall.ECONNRESET), true},
+		{"unexpected eof", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"timeout", fmt.Errorf("post: %w", os.ErrDeadlineExceeded), true},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, retryable(tt.err))
+		})
+	}
+}
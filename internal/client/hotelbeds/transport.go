@@ -0,0 +1,123 @@
+package hotelbeds
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultSearchTimeout  = 5 * time.Second
+	defaultBookingTimeout = 30 * time.Second
+)
+
+// Transport configures the HTTP connections to Hotelbeds, zero values keep the net/http defaults.
+type Transport struct {
+	// MaxIdleConns is the maximum number of idle connections kept in the pool.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept in the pool per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections per host, including those in use.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection stays in the pool.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout is the maximum time waited for a TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ProxyURL is the proxy requests are sent through, the proxy environment variables are used when empty.
+	ProxyURL string
+	// CABundle is the path of a PEM file with certificate authorities trusted on top of the system ones.
+	CABundle string
+}
+
+// Timeouts bounds the duration of every attempt of a request per operation, zero values keep the defaults.
+type Timeouts struct {
+	// Search bounds availability and rate check requests, 5s by default.
+	Search time.Duration
+	// Booking bounds booking confirmation, detail and cancellation requests, 30s by default.
+	Booking time.Duration
+}
+
+func (t Timeouts) withDefaults() Timeouts {
+	if t.Search <= 0 {
+		t.Search = defaultSearchTimeout
+	}
+
+	if t.Booking <= 0 {
+		t.Booking = defaultBookingTimeout
+	}
+
+	return t
+}
+
+// newHTTPClient returns the http.Client configured by t. Timeouts are enforced per operation through the
+// request context, so the client itself has none.
+func newHTTPClient(t Transport) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if t.MaxIdleConns > 0 {
+		transport.MaxIdleConns = t.MaxIdleConns
+	}
+
+	if t.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	}
+
+	if t.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = t.MaxConnsPerHost
+	}
+
+	if t.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = t.IdleConnTimeout
+	}
+
+	if t.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = t.TLSHandshakeTimeout
+	}
+
+	if t.ProxyURL != "" {
+		proxyURL, err := url.Parse(t.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if t.CABundle != "" {
+		rootCAs, err := loadCABundle(t.CABundle)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// loadCABundle returns the system certificate pool with the certificates of the PEM file at path added.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ca bundle: %w", err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, errors.New("ca bundle contains no certificates")
+	}
+
+	return rootCAs, nil
+}
@@ -0,0 +1,119 @@
+package hotelbeds
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"lite-api/internal/client"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		httpClient, err := newHTTPClient(Transport{})
+		require.NoError(t, err)
+		require.Zero(t, httpClient.Timeout)
+
+		transport := httpClient.Transport.(*http.Transport)
+		defaultTransport := http.DefaultTransport.(*http.Transport)
+		require.Equal(t, defaultTransport.MaxIdleConns, transport.MaxIdleConns)
+		require.Equal(t, defaultTransport.IdleConnTimeout, transport.IdleConnTimeout)
+		require.NotNil(t, transport.Proxy)
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		httpClient, err := newHTTPClient(Transport{
+			MaxIdleConns:        50,
+			MaxIdleConnsPerHost: 20,
+			MaxConnsPerHost:     30,
+			IdleConnTimeout:     time.Minute,
+			TLSHandshakeTimeout: 3 * time.Second,
+			ProxyURL:            "http://proxy.internal:3128",
+		})
+		require.NoError(t, err)
+
+		transport := httpClient.Transport.(*http.Transport)
+		require.Equal(t, 50, transport.MaxIdleConns)
+		require.Equal(t, 20, transport.MaxIdleConnsPerHost)
+		require.Equal(t, 30, transport.MaxConnsPerHost)
+		require.Equal(t, time.Minute, transport.IdleConnTimeout)
+		require.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+
+		proxyURL, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "https://api.test.hotelbeds.com", nil))
+		require.NoError(t, err)
+		require.Equal(t, "http://proxy.internal:3128", proxyURL.String())
+	})
+
+	t.Run("invalid proxy url", func(t *testing.T) {
+		_, err := newHTTPClient(Transport{ProxyURL: "://proxy"})
+		require.ErrorContains(t, err, "invalid proxy url")
+	})
+
+	t.Run("missing ca bundle", func(t *testing.T) {
+		_, err := newHTTPClient(Transport{CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+		require.ErrorContains(t, err, "error reading ca bundle")
+	})
+
+	t.Run("ca bundle without certificates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+		_, err := newHTTPClient(Transport{CABundle: path})
+		require.ErrorContains(t, err, "ca bundle contains no certificates")
+	})
+
+	t.Run("ca bundle is trusted", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintln(w, `{}`)
+		}))
+		defer mockServer.Close()
+
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mockServer.Certificate().Raw})
+		require.NoError(t, os.WriteFile(path, certPEM, 0o600))
+
+		hotelBedsCli, err := NewHotelBeds(mockServer.URL, "12345", "6789", Options{
+			Transport: Transport{CABundle: path},
+		}, clock.New(), nil)
+		require.NoError(t, err)
+
+		_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+		require.NoError(t, err)
+	})
+}
+
+func TestHotelBeds_Timeouts(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer mockServer.Close()
+
+	hotelBedsCli, err := NewHotelBeds(mockServer.URL, "12345", "6789", Options{
+		Timeouts: Timeouts{Search: 10 * time.Millisecond, Booking: 5 * time.Second},
+	}, clock.New(), nil)
+	require.NoError(t, err)
+
+	_, err = hotelBedsCli.Search(context.Background(), client.SearchRequest{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = hotelBedsCli.CheckRate(context.Background(), client.CheckRateRequest{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = hotelBedsCli.BookingDetail(context.Background(), "1-1")
+	require.NoError(t, err)
+}
+
+func TestTimeouts_WithDefaults(t *testing.T) {
+	require.Equal(t, Timeouts{Search: defaultSearchTimeout, Booking: defaultBookingTimeout}, Timeouts{}.withDefaults())
+	require.Equal(t, Timeouts{Search: time.Second, Booking: time.Minute},
+		Timeouts{Search: time.Second, Booking: time.Minute}.withDefaults())
+}
@@ -40,6 +40,66 @@ func (m *MockHotelBeds) EXPECT() *MockHotelBedsMockRecorder {
 	return m.recorder
 }
 
+// Book mocks base method.
+func (m *MockHotelBeds) Book(arg0 context.Context, arg1 client.BookingRequest) (client.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", arg0, arg1)
+	ret0, _ := ret[0].(client.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockHotelBedsMockRecorder) Book(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockHotelBeds)(nil).Book), arg0, arg1)
+}
+
+// BookingDetail mocks base method.
+func (m *MockHotelBeds) BookingDetail(ctx context.Context, reference string) (client.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookingDetail", ctx, reference)
+	ret0, _ := ret[0].(client.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookingDetail indicates an expected call of BookingDetail.
+func (mr *MockHotelBedsMockRecorder) BookingDetail(ctx, reference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookingDetail", reflect.TypeOf((*MockHotelBeds)(nil).BookingDetail), ctx, reference)
+}
+
+// CancelBooking mocks base method.
+func (m *MockHotelBeds) CancelBooking(ctx context.Context, reference string, flag client.CancellationFlag) (client.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, reference, flag)
+	ret0, _ := ret[0].(client.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockHotelBedsMockRecorder) CancelBooking(ctx, reference, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockHotelBeds)(nil).CancelBooking), ctx, reference, flag)
+}
+
+// CheckRate mocks base method.
+func (m *MockHotelBeds) CheckRate(arg0 context.Context, arg1 client.CheckRateRequest) (client.CheckRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRate", arg0, arg1)
+	ret0, _ := ret[0].(client.CheckRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRate indicates an expected call of CheckRate.
+func (mr *MockHotelBedsMockRecorder) CheckRate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRate", reflect.TypeOf((*MockHotelBeds)(nil).CheckRate), arg0, arg1)
+}
+
 // Search mocks base method.
 func (m *MockHotelBeds) Search(arg0 context.Context, arg1 client.SearchRequest) (client.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -54,3 +114,101 @@ func (mr *MockHotelBedsMockRecorder) Search(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockHotelBeds)(nil).Search), arg0, arg1)
 }
+
+// MockHotelContent is a mock of HotelContent interface.
+type MockHotelContent struct {
+	ctrl     *gomock.Controller
+	recorder *MockHotelContentMockRecorder
+}
+
+// MockHotelContentMockRecorder is the mock recorder for MockHotelContent.
+type MockHotelContentMockRecorder struct {
+	mock *MockHotelContent
+}
+
+// NewMockHotelContent creates a new mock instance.
+func NewMockHotelContent(ctrl *gomock.Controller) *MockHotelContent {
+	mock := &MockHotelContent{ctrl: ctrl}
+	mock.recorder = &MockHotelContentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHotelContent) EXPECT() *MockHotelContentMockRecorder {
+	return m.recorder
+}
+
+// Boards mocks base method.
+func (m *MockHotelContent) Boards(arg0 context.Context, arg1 client.ContentRequest) (client.ContentBoardsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Boards", arg0, arg1)
+	ret0, _ := ret[0].(client.ContentBoardsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Boards indicates an expected call of Boards.
+func (mr *MockHotelContentMockRecorder) Boards(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Boards", reflect.TypeOf((*MockHotelContent)(nil).Boards), arg0, arg1)
+}
+
+// Countries mocks base method.
+func (m *MockHotelContent) Countries(arg0 context.Context, arg1 client.ContentRequest) (client.ContentCountriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Countries", arg0, arg1)
+	ret0, _ := ret[0].(client.ContentCountriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Countries indicates an expected call of Countries.
+func (mr *MockHotelContentMockRecorder) Countries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Countries", reflect.TypeOf((*MockHotelContent)(nil).Countries), arg0, arg1)
+}
+
+// Destinations mocks base method.
+func (m *MockHotelContent) Destinations(arg0 context.Context, arg1 client.ContentRequest) (client.ContentDestinationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destinations", arg0, arg1)
+	ret0, _ := ret[0].(client.ContentDestinationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Destinations indicates an expected call of Destinations.
+func (mr *MockHotelContentMockRecorder) Destinations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destinations", reflect.TypeOf((*MockHotelContent)(nil).Destinations), arg0, arg1)
+}
+
+// Hotels mocks base method.
+func (m *MockHotelContent) Hotels(arg0 context.Context, arg1 client.ContentRequest) (client.ContentHotelsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hotels", arg0, arg1)
+	ret0, _ := ret[0].(client.ContentHotelsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hotels indicates an expected call of Hotels.
+func (mr *MockHotelContentMockRecorder) Hotels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hotels", reflect.TypeOf((*MockHotelContent)(nil).Hotels), arg0, arg1)
+}
+
+// RoomTypes mocks base method.
+func (m *MockHotelContent) RoomTypes(arg0 context.Context, arg1 client.ContentRequest) (client.ContentRoomTypesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoomTypes", arg0, arg1)
+	ret0, _ := ret[0].(client.ContentRoomTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoomTypes indicates an expected call of RoomTypes.
+func (mr *MockHotelContentMockRecorder) RoomTypes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoomTypes", reflect.TypeOf((*MockHotelContent)(nil).RoomTypes), arg0, arg1)
+}
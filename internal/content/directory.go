@@ -0,0 +1,129 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"lite-api/internal/client"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// HotelSummary is the static data of a hotel added to availability results.
+type HotelSummary struct {
+	Name       string
+	Category   string
+	StarRating float64
+}
+
+// Directory is an in-memory snapshot of the hotels of the Store file at path. The file is only opened while
+// loading, so that the sync command can write it while the server is running.
+type Directory struct {
+	path   string
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	hotels  map[int]HotelSummary
+	modTime time.Time
+}
+
+// LoadDirectory returns the Directory of the Store file at path. A missing file gives an empty Directory,
+// filled by Reload once the file has been synced.
+func LoadDirectory(path string, logger *slog.Logger) (*Directory, error) {
+	d := &Directory{
+		path:   path,
+		logger: logger,
+		hotels: make(map[int]HotelSummary),
+	}
+
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Lookup returns the summary of the hotel with the given code, false if the Directory does not have it.
+func (d *Directory) Lookup(code int) (HotelSummary, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hotel, ok := d.hotels[code]
+	return hotel, ok
+}
+
+// Len returns the number of hotels in the Directory.
+func (d *Directory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return len(d.hotels)
+}
+
+// Reload reads the Store file again if it changed since it was last read.
+func (d *Directory) Reload() error {
+	info, err := os.Stat(d.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	unchanged := info.ModTime().Equal(d.modTime)
+	d.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	store, err := openReadOnly(d.path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	hotels := make(map[int]HotelSummary)
+	err = store.EachHotel(func(hotel client.ContentHotel) error {
+		hotels[hotel.Code] = HotelSummary{
+			Name:       hotel.Name.Content,
+			Category:   hotel.CategoryCode,
+			StarRating: client.StarRating(hotel.CategoryCode),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.hotels, d.modTime = hotels, info.ModTime()
+	d.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the Directory every interval until ctx is done. Failed reloads keep the previous snapshot,
+// e.g. while the sync command holds the file.
+func (d *Directory) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.Reload(); err != nil {
+				d.logger.Warn("error reloading content directory", "err", err)
+			}
+		}
+	}
+}
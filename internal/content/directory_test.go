@@ -0,0 +1,62 @@
+package content
+
+import (
+	"lite-api/internal/client"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectory(t *testing.T) {
+	t.Run("missing store", func(t *testing.T) {
+		directory, err := LoadDirectory(filepath.Join(t.TempDir(), "content.db"), slog.Default())
+		require.NoError(t, err)
+		require.Zero(t, directory.Len())
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		store, path := newStore(t)
+		require.NoError(t, store.PutHotels(client.ContentHotels{
+			{Code: 1067, Name: client.ContentText{Content: "Hotel Bellevue"}, CategoryCode: "3EST"},
+		}))
+		require.NoError(t, store.Close())
+
+		directory, err := LoadDirectory(path, slog.Default())
+		require.NoError(t, err)
+		require.Equal(t, 1, directory.Len())
+
+		hotel, ok := directory.Lookup(1067)
+		require.True(t, ok)
+		require.Equal(t, HotelSummary{Name: "Hotel Bellevue", Category: "3EST", StarRating: 3}, hotel)
+
+		_, ok = directory.Lookup(1068)
+		require.False(t, ok)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		store, path := newStore(t)
+		require.NoError(t, store.Close())
+
+		directory, err := LoadDirectory(path, slog.Default())
+		require.NoError(t, err)
+		require.Zero(t, directory.Len())
+
+		store, err = Open(path)
+		require.NoError(t, err)
+		require.NoError(t, store.PutHotels(client.ContentHotels{{Code: 1067}, {Code: 1068}}))
+
+		// The store is locked while open for writing, the previous snapshot is kept.
+		require.Error(t, directory.Reload())
+		require.Zero(t, directory.Len())
+
+		require.NoError(t, store.Close())
+		modTime := time.Now().Add(time.Second)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		require.NoError(t, directory.Reload())
+		require.Equal(t, 2, directory.Len())
+	})
+}
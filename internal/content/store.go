@@ -0,0 +1,275 @@
+// Package content keeps a local copy of the Hotelbeds Content API static data, so that it does not have to be
+// fetched on every request.
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lite-api/internal/client"
+	"lite-api/internal/mapping"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Kind is a Content API list kept in the Store.
+type Kind string
+
+const (
+	KindCountries    Kind = "countries"
+	KindDestinations Kind = "destinations"
+	KindBoards       Kind = "boards"
+	KindRoomTypes    Kind = "roomTypes"
+	KindHotels       Kind = "hotels"
+)
+
+// Kinds lists every Kind in the order they are synced.
+var Kinds = []Kind{KindCountries, KindDestinations, KindBoards, KindRoomTypes, KindHotels}
+
+var (
+	// bucketMeta stores the last sync date per Kind.
+	bucketMeta = []byte("meta")
+	// bucketMappings stores the hotel mappings imported along with a sync, keyed by supplier and hotel id.
+	bucketMappings = []byte("mappings")
+
+	// ErrUnknownKind is returned for a Kind the Store does not keep.
+	ErrUnknownKind = errors.New("unknown content kind")
+)
+
+const (
+	storeFileMode = 0o600
+	// openTimeout bounds the wait for the file lock, held by the process writing the store.
+	openTimeout = time.Second
+)
+
+// Store is an embedded bbolt database with a bucket per Kind, items are stored as JSON keyed by their code.
+// Only one process at a time may have a Store open for writing.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the Store at path for writing, creating it if needed.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, storeFileMode, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("error opening content store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range append([][]byte{bucketMeta, bucketMappings}, kindBuckets()...) {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error creating content store buckets: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// openReadOnly opens the existing Store at path for reading, sharing the file with other readers.
+func openReadOnly(path string) (*Store, error) {
+	db, err := bolt.Open(path, storeFileMode, &bolt.Options{Timeout: openTimeout, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("error opening content store: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the Store and releases the file lock.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutCountries stores countries, replacing the ones with the same code.
+func (s *Store) PutCountries(countries client.ContentCountries) error {
+	return put(s, KindCountries, countries, func(c client.ContentCountry) string { return c.Code })
+}
+
+// PutDestinations stores destinations, replacing the ones with the same code.
+func (s *Store) PutDestinations(destinations client.ContentDestinations) error {
+	return put(s, KindDestinations, destinations, func(d client.ContentDestination) string { return d.Code })
+}
+
+// PutBoards stores boards, replacing the ones with the same code.
+func (s *Store) PutBoards(boards client.ContentBoards) error {
+	return put(s, KindBoards, boards, func(b client.ContentBoard) string { return b.Code })
+}
+
+// PutRoomTypes stores roomTypes, replacing the ones with the same code.
+func (s *Store) PutRoomTypes(roomTypes client.ContentRoomTypes) error {
+	return put(s, KindRoomTypes, roomTypes, func(r client.ContentRoomType) string { return r.Code })
+}
+
+// PutHotels stores hotels, replacing the ones with the same code.
+func (s *Store) PutHotels(hotels client.ContentHotels) error {
+	return put(s, KindHotels, hotels, func(h client.ContentHotel) string { return strconv.Itoa(h.Code) })
+}
+
+// Hotel returns the hotel with the given code, false if the Store does not have it.
+func (s *Store) Hotel(code int) (client.ContentHotel, bool, error) {
+	var hotel client.ContentHotel
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket([]byte(KindHotels)).Get([]byte(strconv.Itoa(code)))
+		if value == nil {
+			return nil
+		}
+
+		found = true
+		return json.Unmarshal(value, &hotel)
+	})
+
+	return hotel, found, err
+}
+
+// EachHotel calls fn with every hotel of the Store, stopping at the first error.
+func (s *Store) EachHotel(fn func(client.ContentHotel) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(KindHotels)).ForEach(func(_, value []byte) error {
+			var hotel client.ContentHotel
+			if err := json.Unmarshal(value, &hotel); err != nil {
+				return err
+			}
+
+			return fn(hotel)
+		})
+	})
+}
+
+// Count returns the number of items of kind in the Store.
+func (s *Store) Count(kind Kind) (int, error) {
+	var count int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(kind))
+		if bucket == nil {
+			return ErrUnknownKind
+		}
+
+		count = bucket.Stats().KeyN
+		return nil
+	})
+
+	return count, err
+}
+
+// LastSync returns the date, formatted as YYYY-MM-DD, kind was last synced, empty if it never was.
+func (s *Store) LastSync(kind Kind) (string, error) {
+	var lastSync string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		lastSync = string(tx.Bucket(bucketMeta).Get([]byte(kind)))
+		return nil
+	})
+
+	return lastSync, err
+}
+
+// SetLastSync records the date kind was synced.
+func (s *Store) SetLastSync(kind Kind, date string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketMeta).Put([]byte(kind), []byte(date))
+	})
+}
+
+// PutMappings replaces the hotel mappings of the Store with rows, in a single transaction.
+func (s *Store) PutMappings(rows []mapping.Row) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(bucketMappings); err != nil {
+			return err
+		}
+
+		bucket, err := tx.CreateBucket(bucketMappings)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			value, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+
+			key := row.Supplier + "/" + strconv.Itoa(row.HotelID)
+			if err := bucket.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Mappings returns the hotel mappings of the Store, none for a Store synced before mappings were kept.
+func (s *Store) Mappings() ([]mapping.Row, error) {
+	var rows []mapping.Row
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketMappings)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, value []byte) error {
+			var row mapping.Row
+			if err := json.Unmarshal(value, &row); err != nil {
+				return err
+			}
+
+			rows = append(rows, row)
+			return nil
+		})
+	})
+
+	return rows, err
+}
+
+// ReadMappings is a mapping.Reader of the hotel mappings of the Store file at path. The file is only opened
+// while reading, so that the sync command can write it while the server is running.
+func ReadMappings(path string) ([]mapping.Row, error) {
+	store, err := openReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = store.Close()
+	}()
+
+	return store.Mappings()
+}
+
+// put stores items as JSON in the bucket of kind, in a single transaction.
+func put[T any](s *Store, kind Kind, items []T, key func(T) string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(kind))
+		for _, item := range items {
+			value, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put([]byte(key(item)), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// kindBuckets returns the bucket names of every Kind.
+func kindBuckets() [][]byte {
+	buckets := make([][]byte, len(Kinds))
+	for i, kind := range Kinds {
+		buckets[i] = []byte(kind)
+	}
+
+	return buckets
+}
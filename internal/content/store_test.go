@@ -0,0 +1,120 @@
+package content
+
+import (
+	"lite-api/internal/client"
+	"lite-api/internal/mapping"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "content.db")
+	store, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store, path
+}
+
+func TestStore(t *testing.T) {
+	t.Run("hotels", func(t *testing.T) {
+		store, _ := newStore(t)
+		hotels := client.ContentHotels{
+			{Code: 1067, Name: client.ContentText{Content: "Hotel Bellevue"}, CategoryCode: "3EST"},
+			{Code: 1068, Name: client.ContentText{Content: "Apartamentos Sol"}, CategoryCode: "2LL"},
+		}
+		require.NoError(t, store.PutHotels(hotels))
+
+		hotel, found, err := store.Hotel(1067)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, hotels[0], hotel)
+
+		_, found, err = store.Hotel(1)
+		require.NoError(t, err)
+		require.False(t, found)
+
+		var codes []int
+		require.NoError(t, store.EachHotel(func(hotel client.ContentHotel) error {
+			codes = append(codes, hotel.Code)
+			return nil
+		}))
+		require.ElementsMatch(t, []int{1067, 1068}, codes)
+	})
+
+	t.Run("put replaces items with the same code", func(t *testing.T) {
+		store, _ := newStore(t)
+		require.NoError(t, store.PutBoards(client.ContentBoards{{Code: "BB"}, {Code: "RO"}}))
+		require.NoError(t, store.PutBoards(client.ContentBoards{
+			{Code: "BB", Description: client.ContentText{Content: "BED AND BREAKFAST"}},
+		}))
+
+		count, err := store.Count(KindBoards)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		store, _ := newStore(t)
+		require.NoError(t, store.PutCountries(client.ContentCountries{{Code: "ES"}, {Code: "FR"}}))
+		require.NoError(t, store.PutDestinations(client.ContentDestinations{{Code: "PMI"}}))
+		require.NoError(t, store.PutRoomTypes(client.ContentRoomTypes{{Code: "DBL.ST"}}))
+
+		for kind, want := range map[Kind]int{KindCountries: 2, KindDestinations: 1, KindRoomTypes: 1, KindHotels: 0} {
+			count, err := store.Count(kind)
+			require.NoError(t, err)
+			require.Equal(t, want, count, kind)
+		}
+
+		_, err := store.Count("unknown")
+		require.ErrorIs(t, err, ErrUnknownKind)
+	})
+
+	t.Run("last sync", func(t *testing.T) {
+		store, _ := newStore(t)
+		lastSync, err := store.LastSync(KindHotels)
+		require.NoError(t, err)
+		require.Empty(t, lastSync)
+
+		require.NoError(t, store.SetLastSync(KindHotels, "2024-07-01"))
+		lastSync, err = store.LastSync(KindHotels)
+		require.NoError(t, err)
+		require.Equal(t, "2024-07-01", lastSync)
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		store, path := newStore(t)
+		require.NoError(t, store.PutHotels(client.ContentHotels{{Code: 1067}}))
+		require.NoError(t, store.Close())
+
+		store, err := Open(path)
+		require.NoError(t, err)
+		defer store.Close()
+
+		_, found, err := store.Hotel(1067)
+		require.NoError(t, err)
+		require.True(t, found)
+	})
+	t.Run("mappings", func(t *testing.T) {
+		store, path := newStore(t)
+		require.NoError(t, store.PutMappings([]mapping.Row{
+			{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+			{HotelID: 105360, Supplier: "hotelbeds", Code: 77},
+		}))
+
+		// Mappings are replaced rather than merged.
+		rows := []mapping.Row{{HotelID: 129410, Supplier: "hotelbeds", Code: 264}}
+		require.NoError(t, store.PutMappings(rows))
+		require.NoError(t, store.Close())
+
+		read, err := ReadMappings(path)
+		require.NoError(t, err)
+		require.Equal(t, rows, read)
+	})
+}
@@ -0,0 +1,148 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	"log/slog"
+	"time"
+
+	"go.nhat.io/clock"
+)
+
+// SyncOptions tunes a sync.
+type SyncOptions struct {
+	// Language is the three letter Hotelbeds language code of the descriptions.
+	Language string
+	// PageSize is the number of items fetched per Content API request.
+	PageSize int
+	// Full fetches every item instead of only those updated since the last sync.
+	Full bool
+}
+
+// SyncReport is the number of items synced per Kind.
+type SyncReport map[Kind]int
+
+// Syncer copies the Content API lists into a Store.
+type Syncer struct {
+	cli    client.HotelContent
+	store  *Store
+	clock  clock.Clock
+	logger *slog.Logger
+}
+
+// NewSyncer returns Syncer copying the lists of cli into store.
+func NewSyncer(cli client.HotelContent, store *Store, clock clock.Clock, logger *slog.Logger) *Syncer {
+	return &Syncer{
+		cli:    cli,
+		store:  store,
+		clock:  clock,
+		logger: logger,
+	}
+}
+
+// Sync copies every Kind into the store, only fetching items updated since the last sync of the Kind unless
+// opts.Full is set. It stops at the first failure and returns what was synced until then.
+func (s *Syncer) Sync(ctx context.Context, opts SyncOptions) (SyncReport, error) {
+	report := make(SyncReport, len(Kinds))
+	for _, kind := range Kinds {
+		contentReq := client.ContentRequest{Language: opts.Language}
+		if !opts.Full {
+			lastSync, err := s.store.LastSync(kind)
+			if err != nil {
+				return report, err
+			}
+
+			contentReq.LastUpdateTime = lastSync
+		}
+
+		// Items updated while syncing are fetched again next time rather than missed.
+		startedAt := s.clock.Now().UTC().Format(time.DateOnly)
+		s.logger.Info("syncing content", "kind", kind, "since", contentReq.LastUpdateTime)
+
+		synced, err := s.syncKind(ctx, kind, contentReq, opts.PageSize)
+		report[kind] = synced
+		if err != nil {
+			return report, fmt.Errorf("error syncing %s: %w", kind, err)
+		}
+
+		if err := s.store.SetLastSync(kind, startedAt); err != nil {
+			return report, err
+		}
+
+		s.logger.Info("content synced", "kind", kind, "items", synced)
+	}
+
+	return report, nil
+}
+
+func (s *Syncer) syncKind(ctx context.Context, kind Kind, contentReq client.ContentRequest, pageSize int) (int, error) {
+	switch kind {
+	case KindCountries:
+		return syncList(ctx, s.cli.Countries, contentReq, pageSize,
+			func(page client.ContentCountriesResponse) client.ContentCountries { return page.Countries },
+			s.store.PutCountries)
+	case KindDestinations:
+		return syncList(ctx, s.cli.Destinations, contentReq, pageSize,
+			func(page client.ContentDestinationsResponse) client.ContentDestinations { return page.Destinations },
+			s.store.PutDestinations)
+	case KindBoards:
+		return syncList(ctx, s.cli.Boards, contentReq, pageSize,
+			func(page client.ContentBoardsResponse) client.ContentBoards { return page.Boards },
+			s.store.PutBoards)
+	case KindRoomTypes:
+		return syncList(ctx, s.cli.RoomTypes, contentReq, pageSize,
+			func(page client.ContentRoomTypesResponse) client.ContentRoomTypes { return page.Rooms },
+			s.store.PutRoomTypes)
+	case KindHotels:
+		return syncList(ctx, s.cli.Hotels, contentReq, pageSize,
+			func(page client.ContentHotelsResponse) client.ContentHotels { return page.Hotels },
+			s.store.PutHotels)
+	default:
+		return 0, ErrUnknownKind
+	}
+}
+
+// syncList stores every page of the list fetched by fetch and returns the number of items stored.
+func syncList[T client.ContentPager, S ~[]I, I any](ctx context.Context,
+	fetch func(context.Context, client.ContentRequest) (T, error), contentReq client.ContentRequest, pageSize int,
+	items func(T) S, put func(S) error) (int, error) {
+	var synced int
+	err := eachPage(ctx, fetch, contentReq, pageSize, func(page T) error {
+		pageItems := items(page)
+		if err := put(pageItems); err != nil {
+			return err
+		}
+
+		synced += len(pageItems)
+		return nil
+	})
+
+	return synced, err
+}
+
+// eachPage calls fn with every page of pageSize items of the Content API list fetched by fetch,
+// starting at contentReq.From. It stops at the first error returned by fetch or fn.
+func eachPage[T client.ContentPager](ctx context.Context, fetch func(context.Context, client.ContentRequest) (T, error),
+	contentReq client.ContentRequest, pageSize int, fn func(T) error) error {
+	pageSize = min(max(pageSize, 1), client.MaxContentPageSize)
+	from := max(contentReq.From, 1)
+
+	for {
+		contentReq.From, contentReq.To = from, from+pageSize-1
+		page, err := fetch(ctx, contentReq)
+		if err != nil {
+			return fmt.Errorf("error fetching items %d to %d: %w", contentReq.From, contentReq.To, err)
+		}
+
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if page.Page().Count == 0 || contentReq.To >= page.Page().Total {
+			return nil
+		}
+
+		from = contentReq.To + 1
+	}
+}
@@ -0,0 +1,178 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	now := time.Date(2024, 7, 15, 10, 0, 0, 0, time.UTC)
+
+	expectLists := func(cli *hotelbedsmock.MockHotelContent, lastUpdateTime string) {
+		req := client.ContentRequest{Language: "ENG", From: 1, To: 2, LastUpdateTime: lastUpdateTime}
+		cli.EXPECT().Countries(gomock.Any(), req).Return(client.ContentCountriesResponse{
+			From: 1, To: 1, Total: 1, Countries: client.ContentCountries{{Code: "ES"}},
+		}, nil)
+		cli.EXPECT().Destinations(gomock.Any(), req).Return(client.ContentDestinationsResponse{
+			From: 1, To: 1, Total: 1, Destinations: client.ContentDestinations{{Code: "PMI"}},
+		}, nil)
+		cli.EXPECT().Boards(gomock.Any(), req).Return(client.ContentBoardsResponse{
+			From: 1, To: 2, Total: 2, Boards: client.ContentBoards{{Code: "BB"}, {Code: "RO"}},
+		}, nil)
+		cli.EXPECT().RoomTypes(gomock.Any(), req).Return(client.ContentRoomTypesResponse{}, nil)
+	}
+
+	t.Run("full sync pages through every list", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		store, _ := newStore(t)
+		require.NoError(t, store.SetLastSync(KindHotels, "2024-07-01"))
+
+		expectLists(cli, "")
+		gomock.InOrder(
+			cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{Language: "ENG", From: 1, To: 2}).
+				Return(client.ContentHotelsResponse{
+					From: 1, To: 2, Total: 3, Hotels: client.ContentHotels{{Code: 1}, {Code: 2}},
+				}, nil),
+			cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{Language: "ENG", From: 3, To: 4}).
+				Return(client.ContentHotelsResponse{
+					From: 3, To: 3, Total: 3, Hotels: client.ContentHotels{{Code: 3}},
+				}, nil),
+		)
+
+		syncer := NewSyncer(cli, store, clock.Fix(now), slog.Default())
+		report, err := syncer.Sync(context.Background(), SyncOptions{Language: "ENG", PageSize: 2, Full: true})
+		require.NoError(t, err)
+		require.Equal(t, SyncReport{
+			KindCountries:    1,
+			KindDestinations: 1,
+			KindBoards:       2,
+			KindRoomTypes:    0,
+			KindHotels:       3,
+		}, report)
+
+		count, err := store.Count(KindHotels)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		for _, kind := range Kinds {
+			lastSync, err := store.LastSync(kind)
+			require.NoError(t, err)
+			require.Equal(t, "2024-07-15", lastSync, kind)
+		}
+	})
+
+	t.Run("incremental sync since the last sync", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		store, _ := newStore(t)
+		for _, kind := range Kinds {
+			require.NoError(t, store.SetLastSync(kind, "2024-07-01"))
+		}
+
+		expectLists(cli, "2024-07-01")
+		cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{
+			Language: "ENG", From: 1, To: 2, LastUpdateTime: "2024-07-01",
+		}).Return(client.ContentHotelsResponse{}, nil)
+
+		syncer := NewSyncer(cli, store, clock.Fix(now), slog.Default())
+		_, err := syncer.Sync(context.Background(), SyncOptions{Language: "ENG", PageSize: 2})
+		require.NoError(t, err)
+	})
+
+	t.Run("failure keeps the last sync date", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		store, _ := newStore(t)
+		require.NoError(t, store.SetLastSync(KindHotels, "2024-07-01"))
+
+		errBoom := errors.New("boom")
+		expectLists(cli, "")
+		cli.EXPECT().Hotels(gomock.Any(), gomock.Any()).Return(client.ContentHotelsResponse{}, errBoom)
+
+		syncer := NewSyncer(cli, store, clock.Fix(now), slog.Default())
+		report, err := syncer.Sync(context.Background(), SyncOptions{Language: "ENG", PageSize: 2, Full: true})
+		require.ErrorIs(t, err, errBoom)
+		require.Equal(t, 2, report[KindBoards])
+
+		lastSync, err := store.LastSync(KindHotels)
+		require.NoError(t, err)
+		require.Equal(t, "2024-07-01", lastSync)
+	})
+}
+
+func TestEachPage(t *testing.T) {
+	page := func(from, to, total int, codes ...int) client.ContentHotelsResponse {
+		res := client.ContentHotelsResponse{From: from, To: to, Total: total}
+		for _, code := range codes {
+			res.Hotels = append(res.Hotels, client.ContentHotel{Code: code})
+		}
+
+		return res
+	}
+
+	t.Run("pages until total", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		gomock.InOrder(
+			cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{Language: "ENG", From: 1, To: 2}).
+				Return(page(1, 2, 5, 1, 2), nil),
+			cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{Language: "ENG", From: 3, To: 4}).
+				Return(page(3, 4, 5, 3, 4), nil),
+			cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{Language: "ENG", From: 5, To: 6}).
+				Return(page(5, 5, 5, 5), nil),
+		)
+
+		var codes []int
+		err := eachPage(context.Background(), cli.Hotels, client.ContentRequest{Language: "ENG"}, 2,
+			func(res client.ContentHotelsResponse) error {
+				for _, hotel := range res.Hotels {
+					codes = append(codes, hotel.Code)
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3, 4, 5}, codes)
+	})
+
+	t.Run("stops on empty page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		cli.EXPECT().Hotels(gomock.Any(), client.ContentRequest{From: 1, To: client.MaxContentPageSize}).
+			Return(page(1, 0, 10), nil)
+
+		err := eachPage(context.Background(), cli.Hotels, client.ContentRequest{}, client.MaxContentPageSize*2,
+			func(client.ContentHotelsResponse) error { return nil })
+		require.NoError(t, err)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		cli.EXPECT().Hotels(gomock.Any(), gomock.Any()).Return(client.ContentHotelsResponse{}, errors.New("boom"))
+
+		err := eachPage(context.Background(), cli.Hotels, client.ContentRequest{}, 10,
+			func(client.ContentHotelsResponse) error { return nil })
+		require.ErrorContains(t, err, "error fetching items 1 to 10: boom")
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cli := hotelbedsmock.NewMockHotelContent(ctrl)
+		cli.EXPECT().Hotels(gomock.Any(), gomock.Any()).Return(page(1, 10, 20, 1), nil)
+
+		errStop := errors.New("stop")
+		err := eachPage(context.Background(), cli.Hotels, client.ContentRequest{}, 10,
+			func(client.ContentHotelsResponse) error { return errStop })
+		require.ErrorIs(t, err, errStop)
+	})
+}
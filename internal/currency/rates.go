@@ -0,0 +1,187 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRates is returned for a rates file which cannot be used.
+var ErrInvalidRates = errors.New("invalid exchange rates")
+
+// File is the content of a rates file: how many units of every currency one unit of Base is worth, e.g.
+// {"base": "EUR", "rates": {"USD": 1.08, "GBP": 0.85}}.
+type File struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date,omitempty"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// validate checks that every rate is positive and that currencies are ISO 4217 codes.
+func (f File) validate() error {
+	if !isCode(f.Base) {
+		return fmt.Errorf("%w: base currency %q", ErrInvalidRates, f.Base)
+	}
+
+	for currency, rate := range f.Rates {
+		if !isCode(currency) {
+			return fmt.Errorf("%w: currency %q", ErrInvalidRates, currency)
+		}
+
+		if rate <= 0 {
+			return fmt.Errorf("%w: rate of %s is %v", ErrInvalidRates, currency, rate)
+		}
+	}
+
+	return nil
+}
+
+// rate returns the units of to one unit of from is worth, crossing through Base.
+func (f File) rate(from, to string) (float64, bool) {
+	fromRate, ok := f.baseRate(from)
+	if !ok {
+		return 0, false
+	}
+
+	toRate, ok := f.baseRate(to)
+	if !ok {
+		return 0, false
+	}
+
+	return toRate / fromRate, true
+}
+
+func (f File) baseRate(currency string) (float64, bool) {
+	if currency == f.Base {
+		return 1, true
+	}
+
+	rate, ok := f.Rates[currency]
+	return rate, ok
+}
+
+// FileRates is an in-memory snapshot of the rates file at path.
+type FileRates struct {
+	path   string
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	file    File
+	modTime time.Time
+}
+
+// LoadFileRates returns the FileRates of the rates file at path. A missing file gives no rates, filled by Reload
+// once the file is written.
+func LoadFileRates(path string, logger *slog.Logger) (*FileRates, error) {
+	r := &FileRates{
+		path:   path,
+		logger: logger,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Rate returns the units of to one unit of from is worth, false if either currency has no rate.
+func (r *FileRates) Rate(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.file.rate(from, to)
+}
+
+// Len returns the number of currencies with a rate, the base currency included.
+func (r *FileRates) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.file.Base == "" {
+		return 0
+	}
+
+	return len(r.file.Rates) + 1
+}
+
+// Reload reads the rates file again if it changed since it was last read. An invalid file keeps the previous
+// rates.
+func (r *FileRates) Reload() error {
+	info, err := os.Stat(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	unchanged := info.ModTime().Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	raw, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	var file File
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRates, err)
+	}
+
+	if err := file.validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.file, r.modTime = file, info.ModTime()
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the rates every interval until ctx is done. Failed reloads keep the previous rates.
+func (r *FileRates) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(); err != nil {
+				r.logger.Warn("error reloading exchange rates", "err", err)
+			}
+		}
+	}
+}
+
+func isCode(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,104 @@
+package currency
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeRates(t *testing.T, path, rates string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(rates), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileRates(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		rates, err := LoadFileRates(filepath.Join(t.TempDir(), "rates.json"), slog.Default())
+		require.NoError(t, err)
+		require.Zero(t, rates.Len())
+
+		rate, ok := rates.Rate("EUR", "EUR")
+		require.True(t, ok)
+		require.Equal(t, 1.0, rate)
+
+		_, ok = rates.Rate("EUR", "USD")
+		require.False(t, ok)
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		writeRates(t, path, `{"base":"EUR","rates":{"USD":1.25,"GBP":0.8}}`, time.Now())
+
+		rates, err := LoadFileRates(path, slog.Default())
+		require.NoError(t, err)
+		require.Equal(t, 3, rates.Len())
+
+		tests := []struct {
+			from, to string
+			want     float64
+			wantOK   bool
+		}{
+			{"EUR", "USD", 1.25, true},
+			{"USD", "EUR", 0.8, true},
+			{"GBP", "USD", 1.5625, true},
+			{"USD", "USD", 1, true},
+			{"EUR", "JPY", 0, false},
+			{"JPY", "EUR", 0, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.from+tt.to, func(t *testing.T) {
+				rate, ok := rates.Rate(tt.from, tt.to)
+				require.Equal(t, tt.wantOK, ok)
+				require.InDelta(t, tt.want, rate, 1e-9)
+			})
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			rates string
+		}{
+			{"not json", `EUR=1`},
+			{"no base", `{"rates":{"USD":1.25}}`},
+			{"invalid currency", `{"base":"EUR","rates":{"usd":1.25}}`},
+			{"zero rate", `{"base":"EUR","rates":{"USD":0}}`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "rates.json")
+				writeRates(t, path, tt.rates, time.Now())
+
+				_, err := LoadFileRates(path, slog.Default())
+				require.ErrorIs(t, err, ErrInvalidRates)
+			})
+		}
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.json")
+		modTime := time.Now()
+		writeRates(t, path, `{"base":"EUR","rates":{"USD":1.25}}`, modTime)
+
+		rates, err := LoadFileRates(path, slog.Default())
+		require.NoError(t, err)
+
+		// An invalid file keeps the previous rates.
+		writeRates(t, path, `{"base":"EUR","rates":{"USD":-1}}`, modTime.Add(time.Second))
+		require.ErrorIs(t, rates.Reload(), ErrInvalidRates)
+		rate, _ := rates.Rate("EUR", "USD")
+		require.Equal(t, 1.25, rate)
+
+		writeRates(t, path, `{"base":"EUR","rates":{"USD":1.1}}`, modTime.Add(2*time.Second))
+		require.NoError(t, rates.Reload())
+		rate, _ = rates.Rate("EUR", "USD")
+		require.Equal(t, 1.1, rate)
+	})
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at a page of a search whose results are kept by lite-api, so that every page is cut from the
+// same supplier response.
+type Cursor struct {
+	SearchID string `json:"s"`
+	Offset   int    `json:"o"`
+	Limit    int    `json:"l"`
+}
+
+// Encode returns the opaque form of Cursor sent to callers.
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// ParseCursor decodes a Cursor returned by Encode.
+func ParseCursor(s string) (Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if cursor.SearchID == "" || cursor.Offset <= 0 || cursor.Limit <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
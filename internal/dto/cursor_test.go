@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		cursor := Cursor{SearchID: "0123456789abcdef", Offset: 20, Limit: 10}
+		parsed, err := ParseCursor(cursor.Encode())
+		require.NoError(t, err)
+		require.Equal(t, cursor, parsed)
+	})
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "%%%"},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("cursor"))},
+		{"no search", Cursor{Offset: 20, Limit: 10}.Encode()},
+		{"first page", Cursor{SearchID: "abc", Offset: 0, Limit: 10}.Encode()},
+		{"no limit", Cursor{SearchID: "abc", Offset: 20}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursor(tt.cursor)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
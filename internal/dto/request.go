@@ -4,21 +4,118 @@ import (
 	"errors"
 	"lite-api/internal/client"
 	"lite-api/internal/model"
+	"strings"
 )
 
 var (
-	ErrSameDayCheckInAndOut = errors.New("same day check in and out is not allowed")
-	ErrCheckInAfterCheckOut = errors.New("check in after check out is not allowed")
+	ErrSameDayCheckInAndOut  = errors.New("same day check in and out is not allowed")
+	ErrCheckInAfterCheckOut  = errors.New("check in after check out is not allowed")
+	ErrEmptyRateKeys         = errors.New("at least one rate key is required")
+	ErrEmptyRateKey          = errors.New("empty rate key")
+	ErrEmptyHolderName       = errors.New("holder first and last name are required")
+	ErrEmptyBookingRooms     = errors.New("at least one room is required")
+	ErrEmptyGuests           = errors.New("at least one guest per room is required")
+	ErrEmptyGuestName        = errors.New("guest first and last name are required")
+	ErrInvalidGuestType      = errors.New("guest type must be adult or child")
+	ErrInvalidChildAge       = model.ErrChildAgeOutOfRange
+	ErrInvalidClientRef      = errors.New("client reference must be between 1 and 20 characters")
+	ErrInvalidCancelMode     = errors.New("cancellation mode must be simulation or cancellation")
+	ErrInvalidDetail         = errors.New("detail must be empty or rates")
+	ErrInvalidHotelID        = errors.New("hotel id must be a positive number")
+	ErrInvalidLanguage       = errors.New("language must be a three letter code, e.g. ENG")
+	ErrSearchScope           = errors.New("exactly one of hotelIds, destination or latitude and longitude is required")
+	ErrZoneWithoutScope      = errors.New("zone requires a destination")
+	ErrInvalidZone           = errors.New("zone must be a positive number")
+	ErrIncompleteGeolocation = errors.New("latitude, longitude and radius are all required to search around a point")
+	ErrInvalidLatitude       = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude      = errors.New("longitude must be between -180 and 180")
+	ErrInvalidRadius         = errors.New("radius must be positive and at most 200 km")
+	ErrInvalidUnit           = errors.New("unit must be km or mi")
+	ErrInvalidPrice          = errors.New("minPrice and maxPrice must not be negative")
+	ErrPriceRange            = errors.New("minPrice must not be greater than maxPrice")
+	ErrInvalidCategory       = errors.New("minCategory must be between 1 and 5")
+	ErrInvalidPaymentType    = errors.New("paymentType must be AT_WEB or AT_HOTEL")
+	ErrInvalidSort           = errors.New("sort must be price, category, name or distance, prefixed with - for descending order")
+	ErrSortByDistance        = errors.New("sorting by distance requires latitude and longitude")
+	ErrInvalidLimit          = errors.New("limit must be between 0 and 500")
+	ErrInvalidChannel        = errors.New("channel must be at most 32 lowercase letters, digits, - or _")
+	ErrInvalidStars          = errors.New("stars must be between 0 and 5")
+	ErrInvalidNetPrice       = errors.New("netPrice must be positive")
+)
+
+const (
+	// DetailRates requests rooms and rates of each hotel in the search response.
+	DetailRates = "rates"
+
+	// GuestAdult is the guest type for adults.
+	GuestAdult = "adult"
+	// GuestChild is the guest type for children.
+	GuestChild = "child"
+
+	// CancelModeSimulation only reports the cancellation cost.
+	CancelModeSimulation = "simulation"
+	// CancelModeCancellation cancels the booking.
+	CancelModeCancellation = "cancellation"
+
+	maxClientReferenceLen = 20
+
+	// maxRadiusKm is the largest geolocation radius Hotelbeds accepts.
+	maxRadiusKm = 200
+	kmPerMile   = 1.609344
+
+	maxCategory = 5
+
+	// SortPrice sorts search results by price.
+	SortPrice = "price"
+	// SortCategory sorts search results by star rating.
+	SortCategory = "category"
+	// SortName sorts search results by hotel name.
+	SortName = "name"
+	// SortDistance sorts search results by distance from the search center.
+	SortDistance = "distance"
+	// SortDescending prefixes a sort key to sort in descending order, e.g. -price.
+	SortDescending = "-"
+
+	maxSearchLimit = 500
+
+	maxChannelLen = 32
 )
 
 // SearchRequest is the request struct to bind the HTTP request to.
+// The search scope is either HotelIds, Destination, optionally narrowed to one of its zones, or the Radius around
+// Latitude and Longitude.
 type SearchRequest struct {
-	CheckIn          model.DateString    `json:"checkin" form:"checkin" binding:"required"`
-	CheckOut         model.DateString    `json:"checkout" form:"checkout" binding:"required"`
-	Currency         model.Currency      `json:"currency" form:"currency" binding:"required"`
-	GuestNationality model.Country       `json:"guestNationality" form:"guestNationality"`
-	HotelIds         model.IntegerList   `json:"hotelIds" form:"hotelIds" binding:"required"`
-	Occupancies      model.OccupancyList `json:"occupancies" form:"occupancies" binding:"required"`
+	CheckIn          model.DateString      `json:"checkin" form:"checkin" binding:"required"`
+	CheckOut         model.DateString      `json:"checkout" form:"checkout" binding:"required"`
+	Currency         model.Currency        `json:"currency" form:"currency" binding:"required"`
+	GuestNationality model.Country         `json:"guestNationality" form:"guestNationality"`
+	HotelIds         model.IntegerList     `json:"hotelIds" form:"hotelIds"`
+	Destination      model.DestinationCode `json:"destination" form:"destination"`
+	Zone             int                   `json:"zone" form:"zone"`
+	Latitude         *float64              `json:"latitude" form:"latitude"`
+	Longitude        *float64              `json:"longitude" form:"longitude"`
+	Radius           *float64              `json:"radius" form:"radius"`
+	// Unit is the unit of Radius and of the distances in the response, kilometers by default.
+	Unit        client.DistanceUnit `json:"unit" form:"unit"`
+	Occupancies model.OccupancyList `json:"occupancies" form:"occupancies" binding:"required"`
+	Detail      string              `json:"detail,omitempty" form:"detail"`
+	// Boards only keeps rates with one of the board codes, e.g. BB,HB.
+	Boards model.CodeList `json:"boards" form:"boards"`
+	// RefundableOnly drops non-refundable rates, and hotels left without rates.
+	RefundableOnly bool `json:"refundableOnly" form:"refundableOnly"`
+	// MinPrice and MaxPrice bound the selling price of hotels in the requested currency, zero means no bound.
+	MinPrice    float64            `json:"minPrice" form:"minPrice"`
+	MaxPrice    float64            `json:"maxPrice" form:"maxPrice"`
+	MinCategory int                `json:"minCategory" form:"minCategory"`
+	PaymentType client.PaymentType `json:"paymentType" form:"paymentType"`
+	// Sort is the key results are sorted by, in supplier order when empty.
+	Sort string `json:"sort" form:"sort"`
+	// Limit is the number of results per page, every result is returned when zero.
+	Limit int `json:"limit" form:"limit"`
+	// Cursor is the nextCursor of the previous page.
+	Cursor string `json:"cursor" form:"cursor"`
+	// Channel is the sales channel of the search, pricing rules may apply a different markup per channel.
+	Channel string `json:"channel" form:"channel"`
 }
 
 // Validate validates SearchRequest.
@@ -49,6 +146,135 @@ func (s *SearchRequest) Validate() error {
 		return err
 	}
 
+	if err := s.validateScope(); err != nil {
+		return err
+	}
+
+	occupancies, err := s.Occupancies.Parse()
+	if err != nil {
+		return err
+	}
+
+	if err = occupancies.Validate(); err != nil {
+		return err
+	}
+
+	if s.Detail != "" && s.Detail != DetailRates {
+		return ErrInvalidDetail
+	}
+
+	if err := s.validateFilters(); err != nil {
+		return err
+	}
+
+	if err := validateChannel(s.Channel); err != nil {
+		return err
+	}
+
+	return s.validatePaging()
+}
+
+// validateChannel checks channel is empty or a short lowercase identifier, e.g. web or b2b.
+func validateChannel(channel string) error {
+	if len(channel) > maxChannelLen {
+		return ErrInvalidChannel
+	}
+
+	for _, c := range channel {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return ErrInvalidChannel
+		}
+	}
+
+	return nil
+}
+
+// validateFilters checks the filters are valid and do not contradict each other.
+func (s *SearchRequest) validateFilters() error {
+	if _, err := s.Boards.Parse(); err != nil {
+		return err
+	}
+
+	if s.MinPrice < 0 || s.MaxPrice < 0 {
+		return ErrInvalidPrice
+	}
+
+	if s.MaxPrice > 0 && s.MinPrice > s.MaxPrice {
+		return ErrPriceRange
+	}
+
+	if s.MinCategory < 0 || s.MinCategory > maxCategory {
+		return ErrInvalidCategory
+	}
+
+	if s.PaymentType != "" && s.PaymentType != client.PaymentAtWeb && s.PaymentType != client.PaymentAtHotel {
+		return ErrInvalidPaymentType
+	}
+
+	return nil
+}
+
+// validatePaging checks the sort key, the page size and the cursor.
+func (s *SearchRequest) validatePaging() error {
+	if s.Sort != "" {
+		switch key, _ := s.SortKey(); key {
+		case SortPrice, SortCategory, SortName:
+		case SortDistance:
+			if !s.isGeolocation() {
+				return ErrSortByDistance
+			}
+		default:
+			return ErrInvalidSort
+		}
+	}
+
+	if s.Limit < 0 || s.Limit > maxSearchLimit {
+		return ErrInvalidLimit
+	}
+
+	if s.Cursor != "" {
+		if _, err := ParseCursor(s.Cursor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SortKey returns the key of Sort and whether results are sorted in descending order.
+func (s *SearchRequest) SortKey() (string, bool) {
+	key, descending := strings.CutPrefix(s.Sort, SortDescending)
+	return key, descending
+}
+
+// validateScope checks the search has exactly one scope.
+func (s *SearchRequest) validateScope() error {
+	var scopes int
+	for _, set := range []bool{s.HotelIds != "", s.Destination != "", s.isGeolocation()} {
+		if set {
+			scopes++
+		}
+	}
+
+	if scopes != 1 {
+		return ErrSearchScope
+	}
+
+	if s.Zone < 0 {
+		return ErrInvalidZone
+	}
+
+	if s.Zone != 0 && s.Destination == "" {
+		return ErrZoneWithoutScope
+	}
+
+	switch {
+	case s.Destination != "":
+		return s.Destination.Validate()
+	case s.isGeolocation():
+		return s.validateGeolocation()
+	}
+
 	hotelIds, err := s.HotelIds.Parse()
 	if err != nil {
 		return err
@@ -58,18 +284,52 @@ func (s *SearchRequest) Validate() error {
 		return model.ErrEmptyHotelIds
 	}
 
-	occupancies, err := s.Occupancies.Parse()
-	if err != nil {
-		return err
+	return nil
+}
+
+// isGeolocation reports whether the search is scoped to the area around a point.
+func (s *SearchRequest) isGeolocation() bool {
+	return s.Latitude != nil || s.Longitude != nil || s.Radius != nil
+}
+
+func (s *SearchRequest) validateGeolocation() error {
+	if s.Latitude == nil || s.Longitude == nil || s.Radius == nil {
+		return ErrIncompleteGeolocation
 	}
 
-	if err = occupancies.Validate(); err != nil {
-		return err
+	if *s.Latitude < -90 || *s.Latitude > 90 {
+		return ErrInvalidLatitude
+	}
+
+	if *s.Longitude < -180 || *s.Longitude > 180 {
+		return ErrInvalidLongitude
+	}
+
+	radiusKm := *s.Radius
+	switch s.unit() {
+	case client.UnitKilometers:
+	case client.UnitMiles:
+		radiusKm *= kmPerMile
+	default:
+		return ErrInvalidUnit
+	}
+
+	if radiusKm <= 0 || radiusKm > maxRadiusKm {
+		return ErrInvalidRadius
 	}
 
 	return nil
 }
 
+// unit returns Unit, kilometers when it is not set.
+func (s *SearchRequest) unit() client.DistanceUnit {
+	if s.Unit == "" {
+		return client.UnitKilometers
+	}
+
+	return s.Unit
+}
+
 // Transform transforms SearchRequest to client.SearchRequest.
 func (s *SearchRequest) Transform() (client.SearchRequest, error) {
 	occupancies, err := s.Occupancies.Parse()
@@ -77,19 +337,382 @@ func (s *SearchRequest) Transform() (client.SearchRequest, error) {
 		return client.SearchRequest{}, err
 	}
 
+	searchReq := client.SearchRequest{
+		Stay: client.Stay{
+			CheckIn:  s.CheckIn.String(),
+			CheckOut: s.CheckOut.String(),
+		},
+		Occupancies:  transformOccupancies(occupancies),
+		SourceMarket: s.GuestNationality.ISOCode(),
+	}
+
+	boards, err := s.Boards.Parse()
+	if err != nil {
+		return client.SearchRequest{}, err
+	}
+
+	if len(boards) > 0 {
+		searchReq.Boards = &client.Boards{Board: boards, Included: true}
+	}
+
+	// The price bounds are applied to the converted prices rather than sent along, Hotelbeds rates are in the
+	// currency of the hotel.
+	filter := client.Filter{
+		MinCategory: s.MinCategory,
+		PaymentType: s.PaymentType,
+	}
+	if filter != (client.Filter{}) {
+		searchReq.Filter = &filter
+	}
+
+	if s.Destination != "" {
+		searchReq.Destination = &client.Destination{
+			Code: s.Destination.String(),
+			Zone: s.Zone,
+		}
+
+		return searchReq, nil
+	}
+
+	if s.isGeolocation() {
+		if s.Latitude == nil || s.Longitude == nil || s.Radius == nil {
+			return client.SearchRequest{}, ErrIncompleteGeolocation
+		}
+
+		searchReq.Geolocation = &client.Geolocation{
+			Latitude:  *s.Latitude,
+			Longitude: *s.Longitude,
+			Radius:    *s.Radius,
+			Unit:      s.unit(),
+		}
+
+		return searchReq, nil
+	}
+
 	hotelIds, err := s.HotelIds.Parse()
 	if err != nil {
 		return client.SearchRequest{}, err
 	}
 
-	return client.SearchRequest{
-		Stay: client.Stay{
-			CheckIn:  s.CheckIn.String(),
-			CheckOut: s.CheckOut.String(),
-		},
-		Occupancies: occupancies,
-		Hotels: client.HotelIds{
-			Hotel: hotelIds,
+	searchReq.Hotels = &client.HotelIds{
+		Hotel: hotelIds,
+	}
+
+	return searchReq, nil
+}
+
+// transformOccupancies converts occupancies to Hotelbeds occupancies, with a child pax per children age.
+func transformOccupancies(occupancies model.Occupancies) client.Occupancies {
+	transformed := make(client.Occupancies, len(occupancies))
+	for i, occupancy := range occupancies {
+		var paxes client.Paxes
+		for _, age := range occupancy.ChildrenAges {
+			paxes = append(paxes, client.Pax{Type: client.PaxChild, Age: age})
+		}
+
+		transformed[i] = client.Occupancy{
+			Rooms:    occupancy.Rooms,
+			Adults:   occupancy.Adults,
+			Children: occupancy.Children,
+			Paxes:    paxes,
+		}
+	}
+
+	return transformed
+}
+
+// CheckRateRequest is the request struct to bind the rate check HTTP request to.
+type CheckRateRequest struct {
+	RateKeys []string `json:"rateKeys" binding:"required"`
+	// GuestNationality is the guest nationality of the search the rates were found with, so that they are checked
+	// with the supplier account of its market. Optional.
+	GuestNationality model.Country `json:"guestNationality"`
+}
+
+// Validate validates CheckRateRequest.
+func (c *CheckRateRequest) Validate() error {
+	if len(c.RateKeys) == 0 {
+		return ErrEmptyRateKeys
+	}
+
+	if err := validateMarket(c.GuestNationality); err != nil {
+		return err
+	}
+
+	for _, rateKey := range c.RateKeys {
+		if strings.TrimSpace(rateKey) == "" {
+			return ErrEmptyRateKey
+		}
+	}
+
+	return nil
+}
+
+// Transform transforms CheckRateRequest to client.CheckRateRequest.
+func (c *CheckRateRequest) Transform() client.CheckRateRequest {
+	rooms := make(client.CheckRateRooms, len(c.RateKeys))
+	for i, rateKey := range c.RateKeys {
+		rooms[i] = client.CheckRateRoom{RateKey: rateKey}
+	}
+
+	return client.CheckRateRequest{Rooms: rooms}
+}
+
+// BookingRequest is the request struct to bind the booking HTTP request to.
+type BookingRequest struct {
+	Holder          Holder       `json:"holder" binding:"required"`
+	Rooms           BookingRooms `json:"rooms" binding:"required"`
+	ClientReference string       `json:"clientReference" binding:"required"`
+	Remark          string       `json:"remark"`
+	// GuestNationality is the guest nationality of the search the rates were found with, so that they are booked
+	// with the supplier account of its market. Optional.
+	GuestNationality model.Country `json:"guestNationality"`
+}
+
+// Holder is the person the booking is made for.
+type Holder struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// BookingRooms is a collection of BookingRoom.
+type BookingRooms []BookingRoom
+
+// BookingRoom is the rate to be booked along with its guests.
+type BookingRoom struct {
+	RateKey string `json:"rateKey"`
+	Guests  Guests `json:"guests"`
+}
+
+// Guests is a collection of Guest.
+type Guests []Guest
+
+// Guest is a person staying in a booked room.
+type Guest struct {
+	Type      string `json:"type"`
+	Age       int    `json:"age,omitempty"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// Validate validates BookingRequest.
+func (b *BookingRequest) Validate() error {
+	if strings.TrimSpace(b.Holder.FirstName) == "" || strings.TrimSpace(b.Holder.LastName) == "" {
+		return ErrEmptyHolderName
+	}
+
+	if len(b.ClientReference) == 0 || len(b.ClientReference) > maxClientReferenceLen {
+		return ErrInvalidClientRef
+	}
+
+	if len(b.Rooms) == 0 {
+		return ErrEmptyBookingRooms
+	}
+
+	if err := validateMarket(b.GuestNationality); err != nil {
+		return err
+	}
+
+	for _, room := range b.Rooms {
+		if strings.TrimSpace(room.RateKey) == "" {
+			return ErrEmptyRateKey
+		}
+
+		if len(room.Guests) == 0 {
+			return ErrEmptyGuests
+		}
+
+		for _, guest := range room.Guests {
+			if err := guest.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates Guest.
+func (g Guest) Validate() error {
+	if strings.TrimSpace(g.FirstName) == "" || strings.TrimSpace(g.LastName) == "" {
+		return ErrEmptyGuestName
+	}
+
+	switch g.Type {
+	case GuestAdult:
+		return nil
+	case GuestChild:
+		if g.Age < 0 || g.Age > model.MaxChildAge {
+			return ErrInvalidChildAge
+		}
+
+		return nil
+	default:
+		return ErrInvalidGuestType
+	}
+}
+
+// Transform transforms BookingRequest to client.BookingRequest.
+func (b *BookingRequest) Transform() client.BookingRequest {
+	rooms := make(client.BookingRooms, len(b.Rooms))
+	// Hotelbeds numbers the rooms booked with the same rate from 1, the guests of a room are paxes of its number.
+	rateRooms := make(map[string]int, len(b.Rooms))
+	for i, room := range b.Rooms {
+		rateRooms[room.RateKey]++
+		paxes := make(client.Paxes, len(room.Guests))
+		for j, guest := range room.Guests {
+			pax := client.Pax{
+				RoomID:  rateRooms[room.RateKey],
+				Type:    client.PaxAdult,
+				Name:    guest.FirstName,
+				Surname: guest.LastName,
+			}
+
+			if guest.Type == GuestChild {
+				pax.Type = client.PaxChild
+				pax.Age = guest.Age
+			}
+
+			paxes[j] = pax
+		}
+
+		rooms[i] = client.BookingRoom{
+			RateKey: room.RateKey,
+			Paxes:   paxes,
+		}
+	}
+
+	return client.BookingRequest{
+		Holder: client.Holder{
+			Name:    b.Holder.FirstName,
+			Surname: b.Holder.LastName,
 		},
-	}, nil
+		Rooms:           rooms,
+		ClientReference: b.ClientReference,
+		Remark:          b.Remark,
+	}
+}
+
+// BookingDetailRequest is the request struct to bind the booking detail HTTP request to.
+type BookingDetailRequest struct {
+	Reference string `uri:"reference" binding:"required"`
+	// GuestNationality is the guest nationality the booking was made for, so that it is fetched with the supplier
+	// account of its market. Optional.
+	GuestNationality model.Country `form:"guestNationality"`
+}
+
+// Validate validates BookingDetailRequest.
+func (b *BookingDetailRequest) Validate() error {
+	return validateMarket(b.GuestNationality)
+}
+
+// CancelBookingRequest is the request struct to bind the booking cancellation HTTP request to.
+type CancelBookingRequest struct {
+	Reference string `uri:"reference" binding:"required"`
+	Mode      string `form:"mode"`
+	// GuestNationality is the guest nationality the booking was made for, so that it is cancelled with the supplier
+	// account of its market. Optional.
+	GuestNationality model.Country `form:"guestNationality"`
+}
+
+// Validate validates CancelBookingRequest.
+func (c *CancelBookingRequest) Validate() error {
+	switch c.Mode {
+	case "", CancelModeSimulation, CancelModeCancellation:
+	default:
+		return ErrInvalidCancelMode
+	}
+
+	return validateMarket(c.GuestNationality)
+}
+
+// validateMarket checks the optional guest nationality of the requests following a search.
+func validateMarket(nationality model.Country) error {
+	if nationality == "" {
+		return nil
+	}
+
+	return nationality.Validate()
+}
+
+// Flag returns the Hotelbeds cancellation flag for the requested mode, cancellation being the default.
+func (c *CancelBookingRequest) Flag() client.CancellationFlag {
+	if c.Mode == CancelModeSimulation {
+		return client.CancellationSimulation
+	}
+
+	return client.CancellationConfirm
+}
+
+// HotelContentRequest is the request struct to bind the hotel content HTTP request to.
+type HotelContentRequest struct {
+	HotelID int `uri:"id" binding:"required"`
+	// Language is the three letter Hotelbeds language code of the descriptions, English by default.
+	Language string `form:"language"`
+}
+
+// Validate validates HotelContentRequest.
+func (h *HotelContentRequest) Validate() error {
+	if h.HotelID <= 0 {
+		return ErrInvalidHotelID
+	}
+
+	if h.Language == "" {
+		return nil
+	}
+
+	if len(h.Language) != 3 || strings.ToUpper(h.Language) != h.Language || strings.ToLower(h.Language) == h.Language {
+		return ErrInvalidLanguage
+	}
+
+	return nil
+}
+
+// Transform transforms HotelContentRequest into the Content API request of the single hotel.
+func (h *HotelContentRequest) Transform() client.ContentRequest {
+	return client.ContentRequest{
+		Codes:    []int{h.HotelID},
+		Language: h.Language,
+		From:     1,
+		To:       1,
+	}
+}
+
+// PricingMatchRequest is the request struct to bind the pricing rule match HTTP request to, describing the hotel
+// of a search a net price is marked up for.
+type PricingMatchRequest struct {
+	Destination string           `form:"destination"`
+	Stars       float64          `form:"stars"`
+	Channel     string           `form:"channel"`
+	CheckIn     model.DateString `form:"checkin"`
+	NetPrice    float64          `form:"netPrice" binding:"required"`
+}
+
+// Validate validates PricingMatchRequest.
+func (p *PricingMatchRequest) Validate() error {
+	if p.Destination != "" {
+		if err := model.DestinationCode(p.Destination).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.Stars < 0 || p.Stars > maxCategory {
+		return ErrInvalidStars
+	}
+
+	if err := validateChannel(p.Channel); err != nil {
+		return err
+	}
+
+	if p.CheckIn != "" {
+		if _, err := p.CheckIn.Parse(); err != nil {
+			return err
+		}
+	}
+
+	if p.NetPrice <= 0 {
+		return ErrInvalidNetPrice
+	}
+
+	return nil
 }
@@ -9,6 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func TestSearchRequest_Validate(t *testing.T) {
 	validCheckIn := model.DateString(time.Now().Format(time.DateOnly))
 	validCheckOut := model.DateString(time.Now().Add(24 * time.Hour).Format(time.DateOnly))
@@ -26,7 +30,34 @@ func TestSearchRequest_Validate(t *testing.T) {
 				Currency:         model.Currency("USD"),
 				GuestNationality: model.Country("US"),
 				HotelIds:         model.IntegerList("1,2,3"),
-				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":1}]`),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":1,"ChildrenAges":[7]}]`),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Valid destination request",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Destination:      model.DestinationCode("PMI"),
+				Zone:             20,
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Valid geolocation request",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(5.0),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
 			},
 			wantErr: nil,
 		},
@@ -74,7 +105,324 @@ func TestSearchRequest_Validate(t *testing.T) {
 				GuestNationality: model.Country("US"),
 				HotelIds:         model.IntegerList(""),
 			},
-			wantErr: model.ErrEmptyHotelIds,
+			wantErr: ErrSearchScope,
+		},
+		{
+			name: "HotelIds and Destination",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Destination:      model.DestinationCode("PMI"),
+			},
+			wantErr: ErrSearchScope,
+		},
+		{
+			name: "Invalid Destination",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Destination:      model.DestinationCode("pmi"),
+			},
+			wantErr: model.ErrInvalidDestination,
+		},
+		{
+			name: "Zone without Destination",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Zone:             20,
+			},
+			wantErr: ErrZoneWithoutScope,
+		},
+		{
+			name: "Geolocation and HotelIds",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(5.0),
+			},
+			wantErr: ErrSearchScope,
+		},
+		{
+			name: "Geolocation without radius",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+			},
+			wantErr: ErrIncompleteGeolocation,
+		},
+		{
+			name: "Latitude out of range",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(91.0),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(5.0),
+			},
+			wantErr: ErrInvalidLatitude,
+		},
+		{
+			name: "Longitude out of range",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(-180.5),
+				Radius:           ptr(5.0),
+			},
+			wantErr: ErrInvalidLongitude,
+		},
+		{
+			name: "Zero radius",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(0.0),
+			},
+			wantErr: ErrInvalidRadius,
+		},
+		{
+			name: "Radius in miles too large",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(150.0),
+				Unit:             client.UnitMiles,
+			},
+			wantErr: ErrInvalidRadius,
+		},
+		{
+			name: "Invalid unit",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Latitude:         ptr(39.57),
+				Longitude:        ptr(2.65),
+				Radius:           ptr(5.0),
+				Unit:             "m",
+			},
+			wantErr: ErrInvalidUnit,
+		},
+		{
+			name: "Valid filters",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Boards:           model.CodeList("BB,HB"),
+				RefundableOnly:   true,
+				MinPrice:         50,
+				MaxPrice:         200,
+				MinCategory:      3,
+				PaymentType:      client.PaymentAtHotel,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Invalid Boards",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Boards:           model.CodeList("BB,,HB"),
+			},
+			wantErr: model.ErrInvalidCode,
+		},
+		{
+			name: "Negative MinPrice",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				MinPrice:         -1,
+			},
+			wantErr: ErrInvalidPrice,
+		},
+		{
+			name: "MinPrice above MaxPrice",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				MinPrice:         200,
+				MaxPrice:         100,
+			},
+			wantErr: ErrPriceRange,
+		},
+		{
+			name: "MinCategory out of range",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				MinCategory:      6,
+			},
+			wantErr: ErrInvalidCategory,
+		},
+		{
+			name: "Invalid PaymentType",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				PaymentType:      "CASH",
+			},
+			wantErr: ErrInvalidPaymentType,
+		},
+		{
+			name: "Valid paging",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Sort:             "-price",
+				Limit:            20,
+				Cursor:           Cursor{SearchID: "abc", Offset: 20, Limit: 20}.Encode(),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Invalid Sort",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Sort:             "stars",
+			},
+			wantErr: ErrInvalidSort,
+		},
+		{
+			name: "Sort by distance without geolocation",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Sort:             "distance",
+			},
+			wantErr: ErrSortByDistance,
+		},
+		{
+			name: "Limit too large",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Limit:            501,
+			},
+			wantErr: ErrInvalidLimit,
+		},
+		{
+			name: "Invalid Cursor",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Cursor:           "not a cursor",
+			},
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name: "Channel",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Channel:          "b2b",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Invalid Channel",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Channel:          "B2B",
+			},
+			wantErr: ErrInvalidChannel,
+		},
+		{
+			name: "Negative Zone",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				Destination:      model.DestinationCode("PMI"),
+				Zone:             -1,
+			},
+			wantErr: ErrInvalidZone,
 		},
 		{
 			name: "Invalid Occupancies",
@@ -88,6 +436,19 @@ func TestSearchRequest_Validate(t *testing.T) {
 			},
 			wantErr: model.ErrMinOneRoomRequired,
 		},
+		{
+			name: "Invalid Detail",
+			s: &SearchRequest{
+				CheckIn:          validCheckIn,
+				CheckOut:         validCheckOut,
+				Currency:         model.Currency("USD"),
+				GuestNationality: model.Country("US"),
+				HotelIds:         model.IntegerList("1,2,3"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Detail:           "everything",
+			},
+			wantErr: ErrInvalidDetail,
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,13 +480,128 @@ func TestSearchRequest_Transform(t *testing.T) {
 					CheckIn:  "2023-07-01",
 					CheckOut: "2023-07-05",
 				},
-				Occupancies: model.Occupancies{{Rooms: 1, Adults: 2, Children: 1}},
-				Hotels: client.HotelIds{
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 1}},
+				Hotels: &client.HotelIds{
 					Hotel: []int{1, 2, 3},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Children ages as paxes",
+			s: SearchRequest{
+				CheckIn:     model.DateString("2023-07-01"),
+				CheckOut:    model.DateString("2023-07-05"),
+				Occupancies: model.OccupancyList(`[{"rooms":1,"adults":2,"children":2,"childrenAges":[0,9]},{"rooms":1,"adults":1,"children":0}]`),
+				HotelIds:    model.IntegerList("1,2,3"),
+			},
+			want: client.SearchRequest{
+				Stay: client.Stay{
+					CheckIn:  "2023-07-01",
+					CheckOut: "2023-07-05",
+				},
+				Occupancies: client.Occupancies{
+					{
+						Rooms:    1,
+						Adults:   2,
+						Children: 2,
+						Paxes: client.Paxes{
+							{Type: client.PaxChild, Age: 0},
+							{Type: client.PaxChild, Age: 9},
+						},
+					},
+					{Rooms: 1, Adults: 1, Children: 0},
+				},
+				Hotels: &client.HotelIds{
+					Hotel: []int{1, 2, 3},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Guest nationality as source market",
+			s: SearchRequest{
+				CheckIn:          model.DateString("2023-07-01"),
+				CheckOut:         model.DateString("2023-07-05"),
+				Occupancies:      model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":1}]`),
+				HotelIds:         model.IntegerList("1,2,3"),
+				GuestNationality: model.UK,
+			},
+			want: client.SearchRequest{
+				Stay: client.Stay{
+					CheckIn:  "2023-07-01",
+					CheckOut: "2023-07-05",
+				},
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 1}},
+				Hotels: &client.HotelIds{
+					Hotel: []int{1, 2, 3},
+				},
+				SourceMarket: "GB",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Destination and zone",
+			s: SearchRequest{
+				CheckIn:     model.DateString("2023-07-01"),
+				CheckOut:    model.DateString("2023-07-05"),
+				Occupancies: model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Destination: model.DestinationCode("PMI"),
+				Zone:        20,
+			},
+			want: client.SearchRequest{
+				Stay: client.Stay{
+					CheckIn:  "2023-07-01",
+					CheckOut: "2023-07-05",
+				},
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 0}},
+				Destination: &client.Destination{Code: "PMI", Zone: 20},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Geolocation in kilometers by default",
+			s: SearchRequest{
+				CheckIn:     model.DateString("2023-07-01"),
+				CheckOut:    model.DateString("2023-07-05"),
+				Occupancies: model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				Latitude:    ptr(39.57),
+				Longitude:   ptr(2.65),
+				Radius:      ptr(5.0),
+			},
+			want: client.SearchRequest{
+				Stay: client.Stay{
+					CheckIn:  "2023-07-01",
+					CheckOut: "2023-07-05",
+				},
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 0}},
+				Geolocation: &client.Geolocation{Latitude: 39.57, Longitude: 2.65, Radius: 5, Unit: client.UnitKilometers},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Filters",
+			s: SearchRequest{
+				CheckIn:     model.DateString("2023-07-01"),
+				CheckOut:    model.DateString("2023-07-05"),
+				Occupancies: model.OccupancyList(`[{"Rooms":1,"Adults":2,"Children":0}]`),
+				HotelIds:    model.IntegerList("1"),
+				Boards:      model.CodeList("BB, HB"),
+				MinPrice:    50,
+				PaymentType: client.PaymentAtWeb,
+			},
+			want: client.SearchRequest{
+				Stay: client.Stay{
+					CheckIn:  "2023-07-01",
+					CheckOut: "2023-07-05",
+				},
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 0}},
+				Hotels:      &client.HotelIds{Hotel: []int{1}},
+				Boards:      &client.Boards{Board: []string{"BB", "HB"}, Included: true},
+				Filter:      &client.Filter{PaymentType: client.PaymentAtWeb},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Invalid Occupancies",
 			s: SearchRequest{
@@ -161,8 +637,8 @@ func TestSearchRequest_Transform(t *testing.T) {
 					CheckIn:  "2023-07-01",
 					CheckOut: "2023-07-05",
 				},
-				Occupancies: model.Occupancies{},
-				Hotels: client.HotelIds{
+				Occupancies: client.Occupancies{},
+				Hotels: &client.HotelIds{
 					Hotel: []int{1, 2, 3},
 				},
 			},
@@ -181,8 +657,8 @@ func TestSearchRequest_Transform(t *testing.T) {
 					CheckIn:  "2023-07-01",
 					CheckOut: "2023-07-05",
 				},
-				Occupancies: model.Occupancies{{Rooms: 1, Adults: 2, Children: 1}},
-				Hotels:      client.HotelIds{Hotel: nil},
+				Occupancies: client.Occupancies{{Rooms: 1, Adults: 2, Children: 1}},
+				Hotels:      &client.HotelIds{Hotel: nil},
 			},
 			wantErr: true,
 		},
@@ -200,3 +676,302 @@ func TestSearchRequest_Transform(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       CheckRateRequest
+		wantErr error
+	}{
+		{
+			name:    "Valid request",
+			c:       CheckRateRequest{RateKeys: []string{"key-1", "key-2"}},
+			wantErr: nil,
+		},
+		{
+			name:    "No rate keys",
+			c:       CheckRateRequest{},
+			wantErr: ErrEmptyRateKeys,
+		},
+		{
+			name:    "Blank rate key",
+			c:       CheckRateRequest{RateKeys: []string{"key-1", " "}},
+			wantErr: ErrEmptyRateKey,
+		},
+		{
+			name:    "Guest nationality",
+			c:       CheckRateRequest{RateKeys: []string{"key-1"}, GuestNationality: "ES"},
+			wantErr: nil,
+		},
+		{
+			name:    "Guest nationality not allowed",
+			c:       CheckRateRequest{RateKeys: []string{"key-1"}, GuestNationality: "FR"},
+			wantErr: model.ErrCountryNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantErr, tt.c.Validate())
+		})
+	}
+}
+
+func TestCheckRateRequest_Transform(t *testing.T) {
+	c := CheckRateRequest{RateKeys: []string{"key-1", "key-2"}}
+	want := client.CheckRateRequest{
+		Rooms: client.CheckRateRooms{{RateKey: "key-1"}, {RateKey: "key-2"}},
+	}
+
+	require.Equal(t, want, c.Transform())
+}
+
+func TestBookingRequest_Validate(t *testing.T) {
+	validRoom := BookingRoom{
+		RateKey: "some-rate-key",
+		Guests:  Guests{{Type: GuestAdult, FirstName: "Jane", LastName: "Doe"}},
+	}
+
+	tests := []struct {
+		name    string
+		b       BookingRequest
+		wantErr error
+	}{
+		{
+			name: "Valid request",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms:           BookingRooms{validRoom},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Missing holder name",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane"},
+				Rooms:           BookingRooms{validRoom},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrEmptyHolderName,
+		},
+		{
+			name: "Client reference too long",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms:           BookingRooms{validRoom},
+				ClientReference: "LITEAPI-0001-LITEAPI-0001",
+			},
+			wantErr: ErrInvalidClientRef,
+		},
+		{
+			name: "Guest nationality not allowed",
+			b: BookingRequest{
+				Holder:           Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms:            BookingRooms{validRoom},
+				ClientReference:  "LITEAPI-0001",
+				GuestNationality: "FR",
+			},
+			wantErr: model.ErrCountryNotAllowed,
+		},
+		{
+			name: "No rooms",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane", LastName: "Doe"},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrEmptyBookingRooms,
+		},
+		{
+			name: "Empty rate key",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms:           BookingRooms{{Guests: validRoom.Guests}},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrEmptyRateKey,
+		},
+		{
+			name: "No guests",
+			b: BookingRequest{
+				Holder:          Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms:           BookingRooms{{RateKey: "some-rate-key"}},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrEmptyGuests,
+		},
+		{
+			name: "Invalid guest type",
+			b: BookingRequest{
+				Holder: Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms: BookingRooms{{
+					RateKey: "some-rate-key",
+					Guests:  Guests{{Type: "infant", FirstName: "Tom", LastName: "Doe"}},
+				}},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrInvalidGuestType,
+		},
+		{
+			name: "Child too old",
+			b: BookingRequest{
+				Holder: Holder{FirstName: "Jane", LastName: "Doe"},
+				Rooms: BookingRooms{{
+					RateKey: "some-rate-key",
+					Guests:  Guests{{Type: GuestChild, Age: 18, FirstName: "Tom", LastName: "Doe"}},
+				}},
+				ClientReference: "LITEAPI-0001",
+			},
+			wantErr: ErrInvalidChildAge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantErr, tt.b.Validate())
+		})
+	}
+}
+
+func TestBookingRequest_Transform(t *testing.T) {
+	b := BookingRequest{
+		Holder: Holder{FirstName: "Jane", LastName: "Doe"},
+		Rooms: BookingRooms{{
+			RateKey: "some-rate-key",
+			Guests: Guests{
+				{Type: GuestAdult, FirstName: "Jane", LastName: "Doe"},
+				{Type: GuestChild, Age: 8, FirstName: "Tom", LastName: "Doe"},
+			},
+		}},
+		ClientReference: "LITEAPI-0001",
+		Remark:          "Late arrival",
+	}
+
+	want := client.BookingRequest{
+		Holder: client.Holder{Name: "Jane", Surname: "Doe"},
+		Rooms: client.BookingRooms{{
+			RateKey: "some-rate-key",
+			Paxes: client.Paxes{
+				{RoomID: 1, Type: client.PaxAdult, Name: "Jane", Surname: "Doe"},
+				{RoomID: 1, Type: client.PaxChild, Age: 8, Name: "Tom", Surname: "Doe"},
+			},
+		}},
+		ClientReference: "LITEAPI-0001",
+		Remark:          "Late arrival",
+	}
+
+	require.Equal(t, want, b.Transform())
+
+	t.Run("multiple rooms", func(t *testing.T) {
+		b := BookingRequest{
+			Holder: Holder{FirstName: "Jane", LastName: "Doe"},
+			Rooms: BookingRooms{
+				{RateKey: "double-rate-key", Guests: Guests{{Type: GuestAdult, FirstName: "Jane", LastName: "Doe"}}},
+				{RateKey: "double-rate-key", Guests: Guests{{Type: GuestAdult, FirstName: "John", LastName: "Doe"}}},
+				{RateKey: "single-rate-key", Guests: Guests{{Type: GuestAdult, FirstName: "Ann", LastName: "Roe"}}},
+			},
+			ClientReference: "LITEAPI-0002",
+		}
+
+		want := client.BookingRequest{
+			Holder: client.Holder{Name: "Jane", Surname: "Doe"},
+			Rooms: client.BookingRooms{
+				{RateKey: "double-rate-key", Paxes: client.Paxes{{RoomID: 1, Type: client.PaxAdult, Name: "Jane", Surname: "Doe"}}},
+				{RateKey: "double-rate-key", Paxes: client.Paxes{{RoomID: 2, Type: client.PaxAdult, Name: "John", Surname: "Doe"}}},
+				{RateKey: "single-rate-key", Paxes: client.Paxes{{RoomID: 1, Type: client.PaxAdult, Name: "Ann", Surname: "Roe"}}},
+			},
+			ClientReference: "LITEAPI-0002",
+		}
+
+		require.Equal(t, want, b.Transform())
+	})
+}
+
+func TestCancelBookingRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		nationality model.Country
+		wantErr     error
+		wantFlag    client.CancellationFlag
+	}{
+		{name: "Default mode", mode: "", wantFlag: client.CancellationConfirm},
+		{name: "Simulation", mode: CancelModeSimulation, wantFlag: client.CancellationSimulation},
+		{name: "Cancellation", mode: CancelModeCancellation, wantFlag: client.CancellationConfirm},
+		{name: "Invalid mode", mode: "maybe", wantErr: ErrInvalidCancelMode},
+		{name: "Guest nationality", nationality: "UK", wantFlag: client.CancellationConfirm},
+		{name: "Guest nationality not allowed", nationality: "FR", wantErr: model.ErrCountryNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CancelBookingRequest{Reference: "102-4256498", Mode: tt.mode, GuestNationality: tt.nationality}
+			err := c.Validate()
+			require.Equal(t, tt.wantErr, err)
+			if err == nil {
+				require.Equal(t, tt.wantFlag, c.Flag())
+			}
+		})
+	}
+}
+
+func TestHotelContentRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		hotelID  int
+		language string
+		wantErr  error
+	}{
+		{name: "Default language", hotelID: 1067},
+		{name: "Language", hotelID: 1067, language: "CAS"},
+		{name: "Invalid hotel id", hotelID: -1, wantErr: ErrInvalidHotelID},
+		{name: "Lowercase language", hotelID: 1067, language: "eng", wantErr: ErrInvalidLanguage},
+		{name: "Two letter language", hotelID: 1067, language: "EN", wantErr: ErrInvalidLanguage},
+		{name: "Numeric language", hotelID: 1067, language: "123", wantErr: ErrInvalidLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := HotelContentRequest{HotelID: tt.hotelID, Language: tt.language}
+			err := h.Validate()
+			require.Equal(t, tt.wantErr, err)
+			if err == nil {
+				require.Equal(t, client.ContentRequest{
+					Codes:    []int{tt.hotelID},
+					Language: tt.language,
+					From:     1,
+					To:       1,
+				}, h.Transform())
+			}
+		})
+	}
+}
+
+func TestPricingMatchRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     PricingMatchRequest
+		wantErr error
+	}{
+		{name: "Net price only", req: PricingMatchRequest{NetPrice: 100}},
+		{
+			name: "Every condition",
+			req:  PricingMatchRequest{Destination: "PMI", Stars: 4.5, Channel: "web", CheckIn: "2024-07-15", NetPrice: 100},
+		},
+		{name: "Invalid destination", req: PricingMatchRequest{Destination: "pmi", NetPrice: 100}, wantErr: model.ErrInvalidDestination},
+		{name: "Invalid stars", req: PricingMatchRequest{Stars: 6, NetPrice: 100}, wantErr: ErrInvalidStars},
+		{name: "Invalid channel", req: PricingMatchRequest{Channel: "b2b web", NetPrice: 100}, wantErr: ErrInvalidChannel},
+		{name: "Negative net price", req: PricingMatchRequest{NetPrice: -1}, wantErr: ErrInvalidNetPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.wantErr)
+		})
+	}
+
+	t.Run("Invalid check-in", func(t *testing.T) {
+		req := PricingMatchRequest{CheckIn: "15/07/2024", NetPrice: 100}
+		require.Error(t, req.Validate())
+	})
+}
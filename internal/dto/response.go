@@ -1,18 +1,154 @@
 package dto
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	liteapierrors "lite-api/internal/errors"
+	"time"
+)
 
 // SearchResponse is the contract to respond search response with.
 type SearchResponse struct {
-	Data     HotelInfos `json:"data"`
-	Supplier Supplier   `json:"supplier"`
+	Data HotelInfos `json:"data"`
+	// Failures lists the batches of hotel ids whose availability could not be fetched.
+	Failures BatchFailures `json:"failures,omitempty"`
+	// SupplierFailures lists the suppliers which could not be searched while others were.
+	SupplierFailures SupplierFailures `json:"supplierFailures,omitempty"`
+	// Supplier is the first of Suppliers, kept for clients of the API predating multiple suppliers.
+	Supplier Supplier `json:"supplier"`
+	// Suppliers are the payloads exchanged with every supplier searched, only returned with the first page of a
+	// paginated search.
+	Suppliers Suppliers `json:"suppliers"`
+	// Total is the number of results of the search across every page.
+	Total int `json:"total"`
+	// NextCursor fetches the next page, empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Sort is the sort key applied, empty for supplier order.
+	Sort string `json:"sort,omitempty"`
+	// Dropped lists the hotels left out of the results because their price could not be converted or their
+	// supplier code is not mapped.
+	Dropped DroppedHotels `json:"dropped,omitempty"`
+	// Unmapped lists the requested hotel ids which were not sent to a supplier for lack of a mapping.
+	Unmapped UnmappedHotels `json:"unmapped,omitempty"`
+}
+
+const (
+	// DropNoExchangeRate is the reason of hotels priced in a currency without a known rate to the requested one.
+	DropNoExchangeRate = "no_exchange_rate"
+	// DropUnmappedHotel is the reason of hotels whose supplier code maps to no lite API hotel id.
+	DropUnmappedHotel = "unmapped_hotel"
+)
+
+// UnmappedHotels is a collection of UnmappedHotel.
+type UnmappedHotels []UnmappedHotel
+
+// UnmappedHotel reports the requested hotel ids without a code at Supplier.
+type UnmappedHotel struct {
+	Supplier string `json:"supplier"`
+	HotelIds []int  `json:"hotelIds"`
+}
+
+// DroppedHotels is a collection of DroppedHotel.
+type DroppedHotels []DroppedHotel
+
+// DroppedHotel reports an available hotel missing from the results.
+type DroppedHotel struct {
+	HotelID  string `json:"hotelId"`
+	Supplier string `json:"supplier"`
+	Currency string `json:"currency"`
+	Reason   string `json:"reason"`
+}
+
+// Conversion reports the supplier price of a hotel priced in another currency than the requested one.
+type Conversion struct {
+	SourceCurrency string  `json:"sourceCurrency"`
+	SourcePrice    float64 `json:"sourcePrice"`
+	// Rate is the units of the requested currency one unit of the source currency is worth.
+	Rate float64 `json:"rate"`
+}
+
+// SupplierFailures is a collection of SupplierFailure.
+type SupplierFailures []SupplierFailure
+
+// SupplierFailure reports a supplier missing from a partially successful search.
+type SupplierFailure struct {
+	Supplier string      `json:"supplier"`
+	Error    ErrorDetail `json:"error"`
+}
+
+// BatchFailures is a collection of BatchFailure.
+type BatchFailures []BatchFailure
+
+// BatchFailure reports a batch of hotel ids missing from a partially successful search.
+type BatchFailure struct {
+	Supplier string      `json:"supplier,omitempty"`
+	Batch    int         `json:"batch"`
+	HotelIds []int       `json:"hotelIds"`
+	Error    ErrorDetail `json:"error"`
 }
 
 // HotelInfo represents the information related to hotel for the query.
 type HotelInfo struct {
-	HotelID  string  `json:"hotelId"`
+	// HotelID is the lite API id of the hotel when hotel mappings are configured, its code at Supplier otherwise.
+	HotelID  string `json:"hotelId"`
+	Supplier string `json:"supplier"`
+	Currency string `json:"currency"`
+	// Price is the SellingPrice, kept for clients of the API predating pricing rules.
+	Price float64 `json:"price"`
+	// NetPrice is the supplier price and SellingPrice the price after the markup of the matching pricing rule.
+	NetPrice     float64 `json:"netPrice"`
+	SellingPrice float64 `json:"sellingPrice"`
+	// Conversion is only set when NetPrice was converted from the supplier currency.
+	Conversion *Conversion `json:"conversion,omitempty"`
+	// Name and StarRating come from the local content store and are omitted for hotels it does not have.
+	Name       string  `json:"name,omitempty"`
+	StarRating float64 `json:"starRating,omitempty"`
+	// Distance from the search center in the unit of the search, only set for searches around a point.
+	Distance *float64 `json:"distance,omitempty"`
+	// Rooms is only populated when the search is requested with detail=rates.
+	Rooms RoomInfos `json:"rooms,omitempty"`
+}
+
+// RoomInfos is a collection of RoomInfo.
+type RoomInfos []RoomInfo
+
+// RoomInfo represents a bookable room type of a hotel.
+type RoomInfo struct {
+	Code  string    `json:"code"`
+	Name  string    `json:"name"`
+	Rates RateInfos `json:"rates"`
+}
+
+// RateInfos is a collection of RateInfo.
+type RateInfos []RateInfo
+
+// RateInfo represents a bookable rate of a room.
+type RateInfo struct {
+	RateKey     string `json:"rateKey"`
+	RateClass   string `json:"rateClass"`
+	RateType    string `json:"rateType"`
+	BoardCode   string `json:"boardCode"`
+	BoardName   string `json:"boardName"`
+	PaymentType string `json:"paymentType"`
+	Refundable  bool   `json:"refundable"`
+	Allotment   int    `json:"allotment"`
+	// Price is the SellingPrice, as for HotelInfo.
+	Price                float64              `json:"price"`
+	NetPrice             float64              `json:"netPrice"`
+	SellingPrice         float64              `json:"sellingPrice"`
+	CancellationPolicies CancellationPolicies `json:"cancellationPolicies"`
+	Taxes                TaxInfos             `json:"taxes"`
+	TaxesIncluded        bool                 `json:"taxesIncluded"`
+}
+
+// TaxInfos is a collection of TaxInfo.
+type TaxInfos []TaxInfo
+
+// TaxInfo represents a tax applied on a rate.
+type TaxInfo struct {
+	Included bool    `json:"included"`
+	Amount   float64 `json:"amount"`
 	Currency string  `json:"currency"`
-	Price    float64 `json:"price"`
 }
 
 // HotelInfos is a collection of HotelInfo.
@@ -22,8 +158,230 @@ type HotelInfos []HotelInfo
 // Improvement: Request and Response data type is changed to json.RawMessage
 // so that it would not be json string escaped during json.Marshal.
 type Supplier struct {
-	// Request represents the request payload made to Hotelbeds.
+	// Name is the supplier the payloads were exchanged with, omitted for Hotelbeds rate checks and bookings.
+	Name string `json:"name,omitempty"`
+	// Request represents the lite API request the supplier was sent.
 	Request json.RawMessage `json:"request"`
-	// Response represents the response payload received from Hotelbeds.
+	// Response represents the response payload received from the supplier.
 	Response json.RawMessage `json:"response"`
 }
+
+// Suppliers is a collection of Supplier.
+type Suppliers []Supplier
+
+// CheckRateResponse is the contract to respond rate check response with.
+type CheckRateResponse struct {
+	Data     CheckRateInfo `json:"data"`
+	Supplier Supplier      `json:"supplier"`
+}
+
+// CheckRateInfo contains the confirmed price of the checked rates.
+type CheckRateInfo struct {
+	HotelID  string       `json:"hotelId"`
+	CheckIn  string       `json:"checkin"`
+	CheckOut string       `json:"checkout"`
+	Currency string       `json:"currency"`
+	NetPrice float64      `json:"netPrice"`
+	Rates    CheckedRates `json:"rates"`
+}
+
+// CheckedRates is a collection of CheckedRate.
+type CheckedRates []CheckedRate
+
+// CheckedRate contains the confirmed information of a single rate.
+type CheckedRate struct {
+	RateKey              string               `json:"rateKey"`
+	RoomCode             string               `json:"roomCode"`
+	RoomName             string               `json:"roomName"`
+	BoardCode            string               `json:"boardCode"`
+	NetPrice             float64              `json:"netPrice"`
+	RateComments         string               `json:"rateComments,omitempty"`
+	CancellationPolicies CancellationPolicies `json:"cancellationPolicies"`
+}
+
+// CancellationPolicies is a collection of CancellationPolicy.
+type CancellationPolicies []CancellationPolicy
+
+// CancellationPolicy is the penalty charged when cancelling after From.
+type CancellationPolicy struct {
+	Amount float64   `json:"amount"`
+	From   time.Time `json:"from"`
+}
+
+// BookingResponse is the contract to respond booking confirmation, detail and cancellation with.
+type BookingResponse struct {
+	Data     BookingInfo `json:"data"`
+	Supplier Supplier    `json:"supplier"`
+}
+
+// HotelContentResponse is the response struct of the hotel content HTTP request.
+type HotelContentResponse struct {
+	Data HotelContent `json:"data"`
+}
+
+// HotelContent is the static data of a hotel.
+type HotelContent struct {
+	HotelID           string      `json:"hotelId"`
+	Name              string      `json:"name"`
+	Description       string      `json:"description,omitempty"`
+	Category          string      `json:"category"`
+	StarRating        float64     `json:"starRating,omitempty"`
+	Chain             string      `json:"chain,omitempty"`
+	AccommodationType string      `json:"accommodationType,omitempty"`
+	Address           Address     `json:"address"`
+	Coordinates       Coordinates `json:"coordinates"`
+	Email             string      `json:"email,omitempty"`
+	Web               string      `json:"web,omitempty"`
+	Phones            Phones      `json:"phones,omitempty"`
+	Facilities        Facilities  `json:"facilities,omitempty"`
+	Images            Images      `json:"images,omitempty"`
+	LastUpdate        string      `json:"lastUpdate,omitempty"`
+}
+
+// Address is the postal address of a hotel.
+type Address struct {
+	Street          string `json:"street"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	City            string `json:"city"`
+	CountryCode     string `json:"countryCode"`
+	DestinationCode string `json:"destinationCode"`
+	ZoneCode        int    `json:"zoneCode,omitempty"`
+}
+
+// Coordinates is the location of a hotel.
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Phones is a collection of Phone.
+type Phones []Phone
+
+// Phone is a phone number of a hotel.
+type Phone struct {
+	Number string `json:"number"`
+	Type   string `json:"type"`
+}
+
+// Facilities is a collection of Facility.
+type Facilities []Facility
+
+// Facility is a facility offered by a hotel.
+type Facility struct {
+	Code        int    `json:"code"`
+	GroupCode   int    `json:"groupCode"`
+	Description string `json:"description"`
+	// Fee is true when the facility is charged on top of the room price.
+	Fee bool `json:"fee"`
+}
+
+// Images is a collection of Image.
+type Images []Image
+
+// Image is a picture of a hotel, or of one of its rooms when RoomCode is set.
+type Image struct {
+	URL      string `json:"url"`
+	Type     string `json:"type"`
+	Order    int    `json:"order"`
+	RoomCode string `json:"roomCode,omitempty"`
+}
+
+// BookingInfo contains the information about a booking.
+type BookingInfo struct {
+	Reference             string      `json:"reference"`
+	CancellationReference string      `json:"cancellationReference,omitempty"`
+	ClientReference       string      `json:"clientReference"`
+	Status                string      `json:"status"`
+	CreationDate          string      `json:"creationDate"`
+	Holder                Holder      `json:"holder"`
+	HotelID               string      `json:"hotelId"`
+	HotelName             string      `json:"hotelName"`
+	CheckIn               string      `json:"checkin"`
+	CheckOut              string      `json:"checkout"`
+	Currency              string      `json:"currency"`
+	TotalNet              float64     `json:"totalNet"`
+	PendingAmount         float64     `json:"pendingAmount"`
+	CancellationAmount    float64     `json:"cancellationAmount,omitempty"`
+	Rooms                 BookedRooms `json:"rooms"`
+}
+
+// BookedRooms is a collection of BookedRoom.
+type BookedRooms []BookedRoom
+
+// BookedRoom contains a booked room with its guests and rates.
+type BookedRoom struct {
+	Status   string       `json:"status"`
+	RoomCode string       `json:"roomCode"`
+	RoomName string       `json:"roomName"`
+	Guests   Guests       `json:"guests"`
+	Rates    CheckedRates `json:"rates"`
+}
+
+// ErrCodeInternal is the error code of failures which are not caused by the supplier.
+const ErrCodeInternal = "internal_error"
+
+// ErrorResponse is the contract to respond supplier failures with.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes a failure so that callers can act on it without parsing messages.
+type ErrorDetail struct {
+	// Code is the stable machine-readable error code.
+	Code string `json:"code"`
+	// Message is the human-readable error message.
+	Message string `json:"message"`
+	// Retryable tells if the same request may succeed when sent again later.
+	Retryable bool `json:"retryable"`
+	// SupplierCode is the error code reported by the supplier.
+	SupplierCode string `json:"supplierCode,omitempty"`
+	// SupplierStatus is the HTTP status code returned by the supplier.
+	SupplierStatus int `json:"supplierStatus,omitempty"`
+}
+
+// NewErrorResponse builds ErrorResponse from APIErr.
+func NewErrorResponse(err *liteapierrors.APIErr) ErrorResponse {
+	return ErrorResponse{
+		Error: ErrorDetail{
+			Code:           string(err.Kind()),
+			Message:        err.Message(),
+			Retryable:      err.Retryable(),
+			SupplierCode:   err.Code(),
+			SupplierStatus: err.Status(),
+		},
+	}
+}
+
+// NewErrorDetail builds ErrorDetail from any error, errors other than APIErr are reported as internal errors.
+func NewErrorDetail(err error) ErrorDetail {
+	var apiErr *liteapierrors.APIErr
+	if errors.As(err, &apiErr) {
+		return NewErrorResponse(apiErr).Error
+	}
+
+	return ErrorDetail{
+		Code:    ErrCodeInternal,
+		Message: err.Error(),
+	}
+}
+
+// PricingMatchResponse is the contract to respond the pricing rule match with.
+type PricingMatchResponse struct {
+	Data PricingMatch `json:"data"`
+}
+
+// PricingMatch is the selling price of a net price and the pricing rule it was computed with.
+type PricingMatch struct {
+	NetPrice     float64 `json:"netPrice"`
+	SellingPrice float64 `json:"sellingPrice"`
+	// Rule is nil when no rule matched, the selling price is then the net price.
+	Rule *PricingRule `json:"rule"`
+}
+
+// PricingRule describes the markup of a pricing rule.
+type PricingRule struct {
+	Name        string  `json:"name"`
+	Priority    int     `json:"priority"`
+	MarkupType  string  `json:"markupType"`
+	MarkupValue float64 `json:"markupValue"`
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"lite-api/internal/client"
+	"time"
+)
+
+// HeaderSupplierConfig is the request header carrying a SupplierConfigRequest as JSON.
+const HeaderSupplierConfig = "x-liteapi-supplier-config"
+
+const (
+	// minSupplierTimeout is the shortest timeout a request may give its supplier requests, shorter ones would
+	// mostly fail.
+	minSupplierTimeout = time.Second
+	// maxSupplierTimeout is the longest timeout a request may give its supplier requests.
+	maxSupplierTimeout = time.Minute
+)
+
+var (
+	ErrInvalidSupplierConfig = errors.New("x-liteapi-supplier-config must be a JSON object of supplier, account, environment, credentials and timeout")
+	ErrIncompleteCredentials = errors.New("credentials require both apiKey and secret")
+	ErrInvalidAccount        = errors.New("account must be at most 32 lowercase letters, digits, - or _")
+	ErrInvalidEnvironment    = errors.New("environment must be at most 32 lowercase letters, digits, - or _")
+	ErrInvalidTimeout        = errors.New("timeout must be a duration between 1s and 1m, e.g. 5s")
+)
+
+// SupplierConfigRequest is the per-request supplier configuration of the x-liteapi-supplier-config header, e.g.
+// {"supplier": "hotelbeds", "account": "eu", "environment": "live", "timeout": "5s"}.
+type SupplierConfigRequest struct {
+	Supplier    string              `json:"supplier"`
+	Account     string              `json:"account"`
+	Environment string              `json:"environment"`
+	Credentials *CredentialsRequest `json:"credentials"`
+	Timeout     string              `json:"timeout"`
+}
+
+// CredentialsRequest are the supplier credentials of a SupplierConfigRequest.
+type CredentialsRequest struct {
+	ApiKey string `json:"apiKey"`
+	Secret string `json:"secret"`
+}
+
+// ParseSupplierConfig decodes the value of the x-liteapi-supplier-config header, unknown fields are refused so
+// that misspelled ones are not silently ignored.
+func ParseSupplierConfig(header string) (SupplierConfigRequest, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(header))
+	decoder.DisallowUnknownFields()
+
+	var cfg SupplierConfigRequest
+	if err := decoder.Decode(&cfg); err != nil {
+		return SupplierConfigRequest{}, ErrInvalidSupplierConfig
+	}
+
+	return cfg, nil
+}
+
+// Validate validates the SupplierConfigRequest, whether the supplier, environment and credentials may be used is
+// up to the caller.
+func (s SupplierConfigRequest) Validate() error {
+	// Account and environment names follow the same rules as channels.
+	if validateChannel(s.Account) != nil {
+		return ErrInvalidAccount
+	}
+
+	if validateChannel(s.Environment) != nil {
+		return ErrInvalidEnvironment
+	}
+
+	if s.Credentials != nil && (s.Credentials.ApiKey == "" || s.Credentials.Secret == "") {
+		return ErrIncompleteCredentials
+	}
+
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil || timeout < minSupplierTimeout || timeout > maxSupplierTimeout {
+			return ErrInvalidTimeout
+		}
+	}
+
+	return nil
+}
+
+// Transform transforms a validated SupplierConfigRequest to the client.SupplierConfig carried by the request
+// context.
+func (s SupplierConfigRequest) Transform() client.SupplierConfig {
+	cfg := client.SupplierConfig{
+		Supplier:    s.Supplier,
+		Account:     s.Account,
+		Environment: s.Environment,
+	}
+
+	if s.Credentials != nil {
+		cfg.Credentials = &client.Credentials{ApiKey: s.Credentials.ApiKey, Secret: s.Credentials.Secret}
+	}
+
+	if s.Timeout != "" {
+		cfg.Timeout, _ = time.ParseDuration(s.Timeout)
+	}
+
+	return cfg
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"lite-api/internal/client"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSupplierConfig(t *testing.T) {
+	cfg, err := ParseSupplierConfig(`{"supplier":"hotelbeds","account":"eu","environment":"live",` +
+		`"credentials":{"apiKey":"key","secret":"secret"},"timeout":"5s"}`)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, client.SupplierConfig{
+		Supplier:    "hotelbeds",
+		Account:     "eu",
+		Environment: "live",
+		Credentials: &client.Credentials{ApiKey: "key", Secret: "secret"},
+		Timeout:     5 * time.Second,
+	}, cfg.Transform())
+
+	for _, header := range []string{`hotelbeds`, `{"supplier":"hotelbeds","apikey":"key"}`, `[]`} {
+		_, err := ParseSupplierConfig(header)
+		require.ErrorIs(t, err, ErrInvalidSupplierConfig, header)
+	}
+}
+
+func TestSupplierConfigRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SupplierConfigRequest
+		want error
+	}{
+		{"empty", SupplierConfigRequest{}, nil},
+		{"invalid account", SupplierConfigRequest{Account: "EU market"}, ErrInvalidAccount},
+		{"invalid environment", SupplierConfigRequest{Environment: "Live"}, ErrInvalidEnvironment},
+		{"no secret", SupplierConfigRequest{Credentials: &CredentialsRequest{ApiKey: "key"}}, ErrIncompleteCredentials},
+		{"no api key", SupplierConfigRequest{Credentials: &CredentialsRequest{Secret: "secret"}}, ErrIncompleteCredentials},
+		{"invalid timeout", SupplierConfigRequest{Timeout: "5"}, ErrInvalidTimeout},
+		{"negative timeout", SupplierConfigRequest{Timeout: "-5s"}, ErrInvalidTimeout},
+		{"timeout too short", SupplierConfigRequest{Timeout: "1ms"}, ErrInvalidTimeout},
+		{"timeout too long", SupplierConfigRequest{Timeout: "2m"}, ErrInvalidTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.cfg.Validate(), tt.want)
+		})
+	}
+}
@@ -1,19 +1,175 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
 
+// Kind is a stable machine-readable classification of an APIErr.
+// Unlike code, which is whatever the supplier sent, Kind values never change and can be relied on by callers.
+type Kind string
+
+const (
+	// KindInvalidRequest means the supplier rejected the request as malformed.
+	KindInvalidRequest Kind = "invalid_request"
+	// KindNotFound means the supplier does not know the requested resource.
+	KindNotFound Kind = "not_found"
+	// KindSupplierAuth means the supplier refused our credentials.
+	KindSupplierAuth Kind = "supplier_auth_failed"
+	// KindRateLimited means the supplier throttled the request.
+	KindRateLimited Kind = "rate_limited"
+	// KindSupplierUnavailable means the supplier is temporarily down.
+	KindSupplierUnavailable Kind = "supplier_unavailable"
+	// KindSupplierError is any other supplier failure.
+	KindSupplierError Kind = "supplier_error"
+	// KindCircuitOpen means the request was not sent because the supplier failed too often recently.
+	KindCircuitOpen Kind = "circuit_open"
+)
+
+// APIErr is the error returned when a supplier answers with a non-success response.
 type APIErr struct {
-	code    string
-	message string
+	code       string
+	message    string
+	kind       Kind
+	status     int
+	retryable  bool
+	retryAfter time.Duration
 }
 
 func (l *APIErr) Error() string {
 	return fmt.Sprintf("code: %s | message: %s", l.code, l.message)
 }
 
+// Code returns the error code as reported by the supplier.
+func (l *APIErr) Code() string {
+	return l.code
+}
+
+// Message returns the human-readable error message.
+func (l *APIErr) Message() string {
+	return l.message
+}
+
+// Kind returns the stable classification of the error.
+func (l *APIErr) Kind() Kind {
+	return l.kind
+}
+
+// Status returns the upstream HTTP status code, zero if unknown.
+func (l *APIErr) Status() int {
+	return l.status
+}
+
+// Retryable reports whether the same request may succeed if sent again later.
+func (l *APIErr) Retryable() bool {
+	return l.retryable
+}
+
+// RetryAfter returns how long the supplier asked to wait before retrying, zero if it did not say.
+func (l *APIErr) RetryAfter() time.Duration {
+	return l.retryAfter
+}
+
+// WithRetryAfter sets how long the supplier asked to wait before retrying and returns the same APIErr.
+func (l *APIErr) WithRetryAfter(d time.Duration) *APIErr {
+	l.retryAfter = d
+	return l
+}
+
+// HTTPStatus returns the status lite-api should answer its own callers with.
+func (l *APIErr) HTTPStatus() int {
+	switch l.kind {
+	case KindInvalidRequest:
+		return http.StatusBadRequest
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindSupplierUnavailable, KindCircuitOpen:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// NewAPIErr returns a non-retryable supplier error without upstream status information.
 func NewAPIErr(code, message string) *APIErr {
 	return &APIErr{
 		code:    code,
 		message: message,
+		kind:    KindSupplierError,
+	}
+}
+
+// NewUpstreamErr returns an APIErr classified from the upstream HTTP status code.
+func NewUpstreamErr(status int, code, message string) *APIErr {
+	kind, retryable := classify(status)
+
+	return &APIErr{
+		code:      code,
+		message:   message,
+		kind:      kind,
+		status:    status,
+		retryable: retryable,
+	}
+}
+
+// NewCircuitOpenErr returns the retryable error of a request rejected by the circuit breaker of supplier,
+// retryAfter is the time left before the breaker lets a request through again.
+func NewCircuitOpenErr(supplier string, retryAfter time.Duration) *APIErr {
+	return &APIErr{
+		code:       string(KindCircuitOpen),
+		message:    fmt.Sprintf("%s is unavailable, requests are suspended", supplier),
+		kind:       KindCircuitOpen,
+		retryable:  true,
+		retryAfter: retryAfter,
+	}
+}
+
+// NewNotFoundErr returns the error of a resource the supplier does not know, when the supplier answered successfully
+// but without it.
+func NewNotFoundErr(code, message string) *APIErr {
+	return &APIErr{
+		code:    code,
+		message: message,
+		kind:    KindNotFound,
+	}
+}
+
+// NewInvalidRequestErr returns the error of a request lite-api refuses without sending it to the supplier.
+func NewInvalidRequestErr(code, message string) *APIErr {
+	return &APIErr{
+		code:    code,
+		message: message,
+		kind:    KindInvalidRequest,
+	}
+}
+
+// NewQuotaErr returns the retryable error of a request rejected by lite-api to stay within the supplier quota.
+func NewQuotaErr(code, message string, retryAfter time.Duration) *APIErr {
+	return &APIErr{
+		code:       code,
+		message:    message,
+		kind:       KindRateLimited,
+		retryable:  true,
+		retryAfter: retryAfter,
+	}
+}
+
+func classify(status int) (Kind, bool) {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return KindSupplierAuth, false
+	case http.StatusTooManyRequests:
+		return KindRateLimited, true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return KindSupplierUnavailable, true
+	case http.StatusBadRequest:
+		return KindInvalidRequest, false
+	case http.StatusNotFound:
+		return KindNotFound, false
+	default:
+		return KindSupplierError, false
 	}
 }
@@ -1,7 +1,9 @@
 package errors
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -10,3 +12,112 @@ func TestAPIErr_Error(t *testing.T) {
 	err := NewAPIErr("some-code", "some-message")
 	require.Equal(t, "code: some-code | message: some-message", err.Error())
 }
+
+func TestNewUpstreamErr(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantKind      Kind
+		wantRetryable bool
+		wantHTTP      int
+	}{
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			wantKind: KindInvalidRequest,
+			wantHTTP: http.StatusBadRequest,
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			wantKind: KindNotFound,
+			wantHTTP: http.StatusNotFound,
+		},
+		{
+			name:     "unauthorized",
+			status:   http.StatusUnauthorized,
+			wantKind: KindSupplierAuth,
+			wantHTTP: http.StatusBadGateway,
+		},
+		{
+			name:     "forbidden",
+			status:   http.StatusForbidden,
+			wantKind: KindSupplierAuth,
+			wantHTTP: http.StatusBadGateway,
+		},
+		{
+			name:          "too many requests",
+			status:        http.StatusTooManyRequests,
+			wantKind:      KindRateLimited,
+			wantRetryable: true,
+			wantHTTP:      http.StatusTooManyRequests,
+		},
+		{
+			name:          "service unavailable",
+			status:        http.StatusServiceUnavailable,
+			wantKind:      KindSupplierUnavailable,
+			wantRetryable: true,
+			wantHTTP:      http.StatusServiceUnavailable,
+		},
+		{
+			name:          "gateway timeout",
+			status:        http.StatusGatewayTimeout,
+			wantKind:      KindSupplierUnavailable,
+			wantRetryable: true,
+			wantHTTP:      http.StatusServiceUnavailable,
+		},
+		{
+			name:     "internal server error",
+			status:   http.StatusInternalServerError,
+			wantKind: KindSupplierError,
+			wantHTTP: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewUpstreamErr(tt.status, "some-code", "some-message")
+			require.Equal(t, "code: some-code | message: some-message", err.Error())
+			require.Equal(t, tt.status, err.Status())
+			require.Equal(t, tt.wantKind, err.Kind())
+			require.Equal(t, tt.wantRetryable, err.Retryable())
+			require.Equal(t, tt.wantHTTP, err.HTTPStatus())
+		})
+	}
+}
+
+func TestNewCircuitOpenErr(t *testing.T) {
+	err := NewCircuitOpenErr("hotelbeds", 3*time.Second)
+	require.Equal(t, "code: circuit_open | message: hotelbeds is unavailable, requests are suspended", err.Error())
+	require.Equal(t, KindCircuitOpen, err.Kind())
+	require.True(t, err.Retryable())
+	require.Equal(t, 3*time.Second, err.RetryAfter())
+	require.Equal(t, http.StatusServiceUnavailable, err.HTTPStatus())
+	require.Zero(t, err.Status())
+}
+
+func TestNewQuotaErr(t *testing.T) {
+	err := NewQuotaErr("daily_quota_exceeded", "quota used up", time.Hour)
+	require.Equal(t, "code: daily_quota_exceeded | message: quota used up", err.Error())
+	require.Equal(t, KindRateLimited, err.Kind())
+	require.True(t, err.Retryable())
+	require.Equal(t, time.Hour, err.RetryAfter())
+	require.Equal(t, http.StatusTooManyRequests, err.HTTPStatus())
+}
+
+func TestNewNotFoundErr(t *testing.T) {
+	err := NewNotFoundErr("hotel_not_found", "hotel 1 not found")
+	require.Equal(t, "code: hotel_not_found | message: hotel 1 not found", err.Error())
+	require.Equal(t, KindNotFound, err.Kind())
+	require.False(t, err.Retryable())
+	require.Equal(t, http.StatusNotFound, err.HTTPStatus())
+}
+
+func TestNewInvalidRequestErr(t *testing.T) {
+	err := NewInvalidRequestErr("cursor_mismatch", "cursor of another search")
+	require.Equal(t, "code: cursor_mismatch | message: cursor of another search", err.Error())
+	require.Equal(t, KindInvalidRequest, err.Kind())
+	require.False(t, err.Retryable())
+	require.Zero(t, err.Status())
+	require.Equal(t, http.StatusBadRequest, err.HTTPStatus())
+}
@@ -0,0 +1,280 @@
+// Package mapping translates lite API hotel ids to the hotel codes of every supplier and back.
+package mapping
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidMappings is returned for mappings which cannot be used.
+var ErrInvalidMappings = errors.New("invalid hotel mappings")
+
+// csvHeader is the expected first line of a CSV mappings file.
+var csvHeader = []string{"hotel_id", "supplier", "code"}
+
+// Row maps the lite API hotel HotelID to the hotel Code of Supplier.
+type Row struct {
+	HotelID  int    `json:"hotelId"`
+	Supplier string `json:"supplier"`
+	Code     int    `json:"code"`
+}
+
+// File is the content of a JSON mappings file, e.g.
+// {"mappings": [{"hotelId": 129410, "supplier": "hotelbeds", "code": 264}]}.
+type File struct {
+	Mappings []Row `json:"mappings"`
+}
+
+// Reader reads the rows of the mappings at path.
+type Reader func(path string) ([]Row, error)
+
+// ReadFile reads the rows of a CSV or JSON mappings file, told apart by the extension of path.
+func ReadFile(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(f)
+	case ".json":
+		return ParseJSON(f)
+	default:
+		return nil, fmt.Errorf("%w: %s is neither a .csv nor a .json file", ErrInvalidMappings, path)
+	}
+}
+
+// ParseCSV parses mappings with a hotel_id,supplier,code header, e.g. 129410,hotelbeds,264.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMappings, err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	for i, column := range csvHeader {
+		if strings.TrimSpace(records[0][i]) != column {
+			return nil, fmt.Errorf("%w: header must be %s", ErrInvalidMappings, strings.Join(csvHeader, ","))
+		}
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+	for i, record := range records[1:] {
+		hotelID, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: hotel id %q", ErrInvalidMappings, i+2, record[0])
+		}
+
+		code, err := strconv.Atoi(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: code %q", ErrInvalidMappings, i+2, record[2])
+		}
+
+		rows = append(rows, Row{HotelID: hotelID, Supplier: strings.TrimSpace(record[1]), Code: code})
+	}
+
+	return rows, nil
+}
+
+// ParseJSON parses the mappings of a JSON File.
+func ParseJSON(r io.Reader) ([]Row, error) {
+	var file File
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMappings, err)
+	}
+
+	return file.Mappings, nil
+}
+
+// Table is an immutable set of mappings indexed both ways.
+type Table struct {
+	// codes are the hotel codes per supplier and hotel id.
+	codes map[string]map[int]int
+	// hotelIDs are the hotel ids per supplier and hotel code.
+	hotelIDs map[string]map[int]int
+	len      int
+}
+
+// NewTable returns the Table of rows. Ids and codes must be positive and a hotel maps to at most one code per
+// supplier, which maps back to that hotel only.
+func NewTable(rows []Row) (Table, error) {
+	t := Table{
+		codes:    make(map[string]map[int]int),
+		hotelIDs: make(map[string]map[int]int),
+	}
+
+	for _, row := range rows {
+		if row.HotelID <= 0 || row.Code <= 0 || row.Supplier == "" {
+			return Table{}, fmt.Errorf("%w: %+v", ErrInvalidMappings, row)
+		}
+
+		if t.codes[row.Supplier] == nil {
+			t.codes[row.Supplier] = make(map[int]int)
+			t.hotelIDs[row.Supplier] = make(map[int]int)
+		}
+
+		if code, ok := t.codes[row.Supplier][row.HotelID]; ok && code != row.Code {
+			return Table{}, fmt.Errorf("%w: hotel %d is mapped to %s codes %d and %d", ErrInvalidMappings,
+				row.HotelID, row.Supplier, code, row.Code)
+		}
+
+		if hotelID, ok := t.hotelIDs[row.Supplier][row.Code]; ok && hotelID != row.HotelID {
+			return Table{}, fmt.Errorf("%w: %s code %d is mapped to hotels %d and %d", ErrInvalidMappings,
+				row.Supplier, row.Code, hotelID, row.HotelID)
+		}
+
+		if _, ok := t.codes[row.Supplier][row.HotelID]; !ok {
+			t.len++
+		}
+
+		t.codes[row.Supplier][row.HotelID] = row.Code
+		t.hotelIDs[row.Supplier][row.Code] = row.HotelID
+	}
+
+	return t, nil
+}
+
+// Code returns the code of the hotel hotelID at supplier, false if it is not mapped.
+func (t Table) Code(supplier string, hotelID int) (int, bool) {
+	code, ok := t.codes[supplier][hotelID]
+	return code, ok
+}
+
+// HotelID returns the hotel id of the code of supplier, false if it is not mapped.
+func (t Table) HotelID(supplier string, code int) (int, bool) {
+	hotelID, ok := t.hotelIDs[supplier][code]
+	return hotelID, ok
+}
+
+// Len returns the number of mappings of the Table.
+func (t Table) Len() int {
+	return t.len
+}
+
+// Mappings is an in-memory snapshot of the mappings at path, read with a Reader.
+type Mappings struct {
+	path   string
+	read   Reader
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	table   Table
+	modTime time.Time
+}
+
+// Load returns the Mappings read by read from path. A missing file gives no mappings, filled by Reload once
+// the file is written.
+func Load(path string, read Reader, logger *slog.Logger) (*Mappings, error) {
+	m := &Mappings{
+		path:   path,
+		read:   read,
+		logger: logger,
+	}
+
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Code returns the code of the hotel hotelID at supplier, false if it is not mapped.
+func (m *Mappings) Code(supplier string, hotelID int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.table.Code(supplier, hotelID)
+}
+
+// HotelID returns the hotel id of the code of supplier, false if it is not mapped.
+func (m *Mappings) HotelID(supplier string, code int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.table.HotelID(supplier, code)
+}
+
+// Len returns the number of mappings.
+func (m *Mappings) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.table.Len()
+}
+
+// Reload reads the mappings again if the file changed since it was last read. Invalid mappings keep the
+// previous ones.
+func (m *Mappings) Reload() error {
+	info, err := os.Stat(m.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	unchanged := info.ModTime().Equal(m.modTime)
+	m.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	rows, err := m.read(m.path)
+	if err != nil {
+		return err
+	}
+
+	table, err := NewTable(rows)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.table, m.modTime = table, info.ModTime()
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the mappings every interval until ctx is done. Failed reloads keep the previous mappings.
+func (m *Mappings) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Reload(); err != nil {
+				m.logger.Warn("error reloading hotel mappings", "err", err)
+			}
+		}
+	}
+}
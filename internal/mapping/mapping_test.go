@@ -0,0 +1,148 @@
+package mapping
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeMappings(t *testing.T, path, mappings string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(mappings), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("hotel_id,supplier,code\n129410, hotelbeds, 264\n105360,hotelbeds,77\n"))
+	require.NoError(t, err)
+	require.Equal(t, []Row{
+		{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+		{HotelID: 105360, Supplier: "hotelbeds", Code: 77},
+	}, rows)
+
+	rows, err = ParseCSV(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"no header", "129410,hotelbeds,264\n"},
+		{"missing column", "hotel_id,supplier,code\n129410,hotelbeds\n"},
+		{"invalid hotel id", "hotel_id,supplier,code\nabc,hotelbeds,264\n"},
+		{"invalid code", "hotel_id,supplier,code\n129410,hotelbeds,abc\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tt.csv))
+			require.ErrorIs(t, err, ErrInvalidMappings)
+		})
+	}
+}
+
+func TestNewTable(t *testing.T) {
+	table, err := NewTable([]Row{
+		{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+		{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+		{HotelID: 129410, Supplier: "other", Code: 9},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, table.Len())
+
+	code, ok := table.Code("hotelbeds", 129410)
+	require.True(t, ok)
+	require.Equal(t, 264, code)
+
+	hotelID, ok := table.HotelID("other", 9)
+	require.True(t, ok)
+	require.Equal(t, 129410, hotelID)
+
+	_, ok = table.Code("hotelbeds", 105360)
+	require.False(t, ok)
+
+	_, ok = table.HotelID("unknown", 264)
+	require.False(t, ok)
+
+	tests := []struct {
+		name string
+		rows []Row
+	}{
+		{"zero hotel id", []Row{{Supplier: "hotelbeds", Code: 264}}},
+		{"zero code", []Row{{HotelID: 129410, Supplier: "hotelbeds"}}},
+		{"no supplier", []Row{{HotelID: 129410, Code: 264}}},
+		{"hotel mapped twice", []Row{
+			{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+			{HotelID: 129410, Supplier: "hotelbeds", Code: 77},
+		}},
+		{"code mapped twice", []Row{
+			{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+			{HotelID: 105360, Supplier: "hotelbeds", Code: 264},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTable(tt.rows)
+			require.ErrorIs(t, err, ErrInvalidMappings)
+		})
+	}
+}
+
+func TestMappings(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		mappings, err := Load(filepath.Join(t.TempDir(), "mappings.csv"), ReadFile, slog.Default())
+		require.NoError(t, err)
+		require.Zero(t, mappings.Len())
+
+		_, ok := mappings.Code("hotelbeds", 129410)
+		require.False(t, ok)
+	})
+
+	t.Run("json file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mappings.json")
+		writeMappings(t, path, `{"mappings":[{"hotelId":129410,"supplier":"hotelbeds","code":264}]}`, time.Now())
+
+		mappings, err := Load(path, ReadFile, slog.Default())
+		require.NoError(t, err)
+		require.Equal(t, 1, mappings.Len())
+
+		hotelID, ok := mappings.HotelID("hotelbeds", 264)
+		require.True(t, ok)
+		require.Equal(t, 129410, hotelID)
+	})
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mappings.txt")
+		writeMappings(t, path, "129410 hotelbeds 264", time.Now())
+
+		_, err := Load(path, ReadFile, slog.Default())
+		require.ErrorIs(t, err, ErrInvalidMappings)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mappings.csv")
+		modTime := time.Now()
+		writeMappings(t, path, "hotel_id,supplier,code\n129410,hotelbeds,264\n", modTime)
+
+		mappings, err := Load(path, ReadFile, slog.Default())
+		require.NoError(t, err)
+
+		// Invalid mappings keep the previous ones.
+		writeMappings(t, path, "hotel_id,supplier,code\n129410,hotelbeds,0\n", modTime.Add(time.Second))
+		require.ErrorIs(t, mappings.Reload(), ErrInvalidMappings)
+		code, _ := mappings.Code("hotelbeds", 129410)
+		require.Equal(t, 264, code)
+
+		writeMappings(t, path, "hotel_id,supplier,code\n129410,hotelbeds,77\n", modTime.Add(2*time.Second))
+		require.NoError(t, mappings.Reload())
+		code, _ = mappings.Code("hotelbeds", 129410)
+		require.Equal(t, 77, code)
+	})
+}
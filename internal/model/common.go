@@ -17,6 +17,17 @@ var (
 	ErrNegativeValue       = errors.New("negative value not allowed")
 	ErrEmptyOccupancies    = errors.New("empty occupancies")
 	ErrEmptyHotelIds       = errors.New("empty hotel ids")
+	ErrChildrenAgesCount   = errors.New("number of children ages must match number of children")
+	ErrChildAgeOutOfRange  = errors.New("child age must be between 0 and 17")
+	ErrInvalidDestination  = errors.New("destination must be a three character Hotelbeds destination code, e.g. PMI")
+	ErrInvalidCode         = errors.New("codes must be comma separated upper case letters or digits")
+)
+
+const (
+	// MaxChildAge is the oldest age a guest is considered a child.
+	MaxChildAge = 17
+
+	destinationCodeLen = 3
 )
 
 var (
@@ -75,6 +86,16 @@ func (c Country) String() string {
 	return string(c)
 }
 
+// ISOCode returns the ISO 3166-1 alpha-2 code of the Country.
+// lite API accepts UK for United Kingdom while the ISO code is GB.
+func (c Country) ISOCode() string {
+	if c == UK {
+		return "GB"
+	}
+
+	return string(c)
+}
+
 // Validate checks if search in the specified country is allowed.
 func (c Country) Validate() error {
 	for _, country := range allowedCountries {
@@ -86,6 +107,67 @@ func (c Country) Validate() error {
 	return ErrCountryNotAllowed
 }
 
+// DestinationCode is a Hotelbeds destination code, e.g. PMI for Majorca.
+type DestinationCode string
+
+// String returns DestinationCode as a string.
+func (d DestinationCode) String() string {
+	return string(d)
+}
+
+// Validate checks DestinationCode is made of three upper case letters or digits.
+func (d DestinationCode) Validate() error {
+	if len(d) != destinationCodeLen {
+		return ErrInvalidDestination
+	}
+
+	if !isCode(string(d)) {
+		return ErrInvalidDestination
+	}
+
+	return nil
+}
+
+// CodeList represents a list of Hotelbeds codes that can be initialized from a comma-separated string, e.g. BB,HB.
+type CodeList string
+
+// String returns CodeList as string.
+func (c CodeList) String() string {
+	return string(c)
+}
+
+// Parse splits the CodeList, an empty CodeList has no codes.
+func (c CodeList) Parse() ([]string, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	codes := strings.Split(c.String(), ",")
+	for i, code := range codes {
+		codes[i] = strings.TrimSpace(code)
+		if !isCode(codes[i]) {
+			return nil, ErrInvalidCode
+		}
+	}
+
+	return codes, nil
+}
+
+// isCode reports whether s is made of upper case letters or digits only.
+func isCode(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
 // DateString represents a date string DateOnly format.
 type DateString string
 
@@ -133,11 +215,12 @@ func (i IntegerList) Parse() ([]int, error) {
 	return nums, nil
 }
 
-// Occupancy represents the requested occupancy details in Hotelbeds request.
+// Occupancy represents the requested occupancy details.
 type Occupancy struct {
-	Adults   int `json:"adults"`
-	Children int `json:"children"`
-	Rooms    int `json:"rooms"`
+	Adults       int   `json:"adults"`
+	Children     int   `json:"children"`
+	ChildrenAges []int `json:"childrenAges,omitempty"`
+	Rooms        int   `json:"rooms"`
 }
 
 // Occupancies is a collection of Occupancy.
@@ -181,6 +264,15 @@ func (o Occupancies) Validate() error {
 			return ErrNegativeValue
 		}
 
+		if len(occupancy.ChildrenAges) != occupancy.Children {
+			return ErrChildrenAgesCount
+		}
+
+		for _, age := range occupancy.ChildrenAges {
+			if age < 0 || age > MaxChildAge {
+				return ErrChildAgeOutOfRange
+			}
+		}
 	}
 
 	return nil
@@ -67,6 +67,90 @@ func TestCountry_Validate(t *testing.T) {
 	}
 }
 
+func TestCountry_ISOCode(t *testing.T) {
+	require.Equal(t, "US", US.ISOCode())
+	require.Equal(t, "ES", ES.ISOCode())
+	require.Equal(t, "GB", UK.ISOCode())
+}
+
+func TestDestinationCode_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination DestinationCode
+		wantErr     error
+	}{
+		{
+			name:        "Valid destination",
+			destination: DestinationCode("PMI"),
+			wantErr:     nil,
+		},
+		{
+			name:        "Digits",
+			destination: DestinationCode("1GC"),
+			wantErr:     nil,
+		},
+		{
+			name:        "Lower case",
+			destination: DestinationCode("pmi"),
+			wantErr:     ErrInvalidDestination,
+		},
+		{
+			name:        "Too long",
+			destination: DestinationCode("PALMA"),
+			wantErr:     ErrInvalidDestination,
+		},
+		{
+			name:        "Empty destination",
+			destination: DestinationCode(""),
+			wantErr:     ErrInvalidDestination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantErr, tt.destination.Validate())
+		})
+	}
+}
+
+func TestCodeList_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    CodeList
+		expected []string
+		wantErr  error
+	}{
+		{
+			name:     "Valid input",
+			input:    "BB,HB, AI",
+			expected: []string{"BB", "HB", "AI"},
+		},
+		{
+			name:     "Empty input",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:    "Empty code",
+			input:   "BB,",
+			wantErr: ErrInvalidCode,
+		},
+		{
+			name:    "Lower case code",
+			input:   "bb",
+			wantErr: ErrInvalidCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codes, err := tt.input.Parse()
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.expected, codes)
+		})
+	}
+}
+
 func TestDateString_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -199,6 +283,14 @@ func TestOccupancyList_Parse(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Occupancy with children ages",
+			o:    OccupancyList(`[{"rooms":1,"adults":2,"children":2,"childrenAges":[3,11]}]`),
+			want: Occupancies{
+				{Rooms: 1, Adults: 2, Children: 2, ChildrenAges: []int{3, 11}},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "Empty occupancy list",
 			o:       OccupancyList(`[]`),
@@ -241,11 +333,39 @@ func TestOccupancies_Validate(t *testing.T) {
 		{
 			name: "Valid occupancies",
 			o: Occupancies{
-				{Rooms: 1, Adults: 2, Children: 1},
+				{Rooms: 1, Adults: 2, Children: 1, ChildrenAges: []int{4}},
 				{Rooms: 2, Adults: 1, Children: 0},
 			},
 			wantErr: nil,
 		},
+		{
+			name: "Invalid: missing children ages",
+			o: Occupancies{
+				{Rooms: 1, Adults: 2, Children: 2, ChildrenAges: []int{4}},
+			},
+			wantErr: ErrChildrenAgesCount,
+		},
+		{
+			name: "Invalid: ages without children",
+			o: Occupancies{
+				{Rooms: 1, Adults: 2, Children: 0, ChildrenAges: []int{4}},
+			},
+			wantErr: ErrChildrenAgesCount,
+		},
+		{
+			name: "Invalid: child age out of range",
+			o: Occupancies{
+				{Rooms: 1, Adults: 2, Children: 2, ChildrenAges: []int{4, 18}},
+			},
+			wantErr: ErrChildAgeOutOfRange,
+		},
+		{
+			name: "Invalid: negative child age",
+			o: Occupancies{
+				{Rooms: 1, Adults: 2, Children: 1, ChildrenAges: []int{-1}},
+			},
+			wantErr: ErrChildAgeOutOfRange,
+		},
 		{
 			name: "Invalid: zero rooms",
 			o: Occupancies{
@@ -1,6 +1,10 @@
 package cli
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 const (
 	AppPortEnv           = "APP_PORT"
@@ -12,6 +16,102 @@ const (
 	AppModeEnv           = "MODE"
 	DefaultAppMode       = "dev"
 	LogLevel             = "LOG_LEVEL"
+
+	SearchBatchSizeEnv       = "SEARCH_BATCH_SIZE"
+	DefaultSearchBatchSize   = 100
+	SearchConcurrencyEnv     = "SEARCH_CONCURRENCY"
+	DefaultSearchConcurrency = 4
+
+	SearchCacheTTLEnv           = "SEARCH_CACHE_TTL"
+	DefaultSearchCacheTTL       = time.Duration(0)
+	SearchCacheMaxBytesEnv      = "SEARCH_CACHE_MAX_BYTES"
+	DefaultSearchCacheMaxBytes  = 64 << 20
+	SearchCursorTTLEnv          = "SEARCH_CURSOR_TTL"
+	DefaultSearchCursorTTL      = 10 * time.Minute
+	SearchCursorMaxBytesEnv     = "SEARCH_CURSOR_MAX_BYTES"
+	DefaultSearchCursorMaxBytes = 64 << 20
+
+	RetryMaxAttemptsEnv     = "HOTELBEDS_RETRY_MAX_ATTEMPTS"
+	DefaultRetryMaxAttempts = 3
+	RetryBaseDelayEnv       = "HOTELBEDS_RETRY_BASE_DELAY"
+	DefaultRetryBaseDelay   = 100 * time.Millisecond
+	RetryMaxDelayEnv        = "HOTELBEDS_RETRY_MAX_DELAY"
+	DefaultRetryMaxDelay    = 2 * time.Second
+	RetryJitterEnv          = "HOTELBEDS_RETRY_JITTER"
+	DefaultRetryJitter      = 0.5
+	RetryBudgetEnv          = "HOTELBEDS_RETRY_BUDGET"
+	DefaultRetryBudget      = 0.1
+
+	CircuitFailureRateEnv     = "HOTELBEDS_CIRCUIT_FAILURE_RATE"
+	DefaultCircuitFailureRate = 0.5
+	CircuitWindowEnv          = "HOTELBEDS_CIRCUIT_WINDOW"
+	DefaultCircuitWindow      = 20
+	CircuitMinRequestsEnv     = "HOTELBEDS_CIRCUIT_MIN_REQUESTS"
+	DefaultCircuitMinRequests = 10
+	CircuitCoolDownEnv        = "HOTELBEDS_CIRCUIT_COOL_DOWN"
+	DefaultCircuitCoolDown    = 30 * time.Second
+
+	RateLimitPerSecondEnv     = "HOTELBEDS_RATE_LIMIT"
+	DefaultRateLimitPerSecond = 0.0
+	RateLimitBurstEnv         = "HOTELBEDS_RATE_BURST"
+	DefaultRateLimitBurst     = 1
+	DailyQuotaEnv             = "HOTELBEDS_DAILY_QUOTA"
+	DefaultDailyQuota         = 0
+
+	MaxIdleConnsEnv                = "HOTELBEDS_MAX_IDLE_CONNS"
+	DefaultMaxIdleConns            = 100
+	MaxIdleConnsPerHostEnv         = "HOTELBEDS_MAX_IDLE_CONNS_PER_HOST"
+	DefaultMaxIdleConnsPerHost     = 10
+	MaxConnsPerHostEnv             = "HOTELBEDS_MAX_CONNS_PER_HOST"
+	DefaultMaxConnsPerHost         = 0
+	IdleConnTimeoutEnv             = "HOTELBEDS_IDLE_CONN_TIMEOUT"
+	DefaultIdleConnTimeout         = 90 * time.Second
+	TLSHandshakeTimeoutEnv         = "HOTELBEDS_TLS_HANDSHAKE_TIMEOUT"
+	DefaultTLSHandshakeTimeout     = 10 * time.Second
+	HotelbedsProxyURLEnv           = "HOTELBEDS_PROXY_URL"
+	HotelbedsCABundleEnv           = "HOTELBEDS_CA_BUNDLE"
+	HotelbedsSearchTimeoutEnv      = "HOTELBEDS_SEARCH_TIMEOUT"
+	DefaultHotelbedsSearchTimeout  = 5 * time.Second
+	HotelbedsBookingTimeoutEnv     = "HOTELBEDS_BOOKING_TIMEOUT"
+	DefaultHotelbedsBookingTimeout = 30 * time.Second
+
+	ContentStorePathEnv          = "CONTENT_STORE_PATH"
+	DefaultContentStorePath      = "content.db"
+	ContentReloadIntervalEnv     = "CONTENT_RELOAD_INTERVAL"
+	DefaultContentReloadInterval = time.Minute
+	ContentLanguageEnv           = "CONTENT_LANGUAGE"
+	DefaultContentLanguage       = "ENG"
+	ContentPageSizeEnv           = "CONTENT_PAGE_SIZE"
+	DefaultContentPageSize       = 1000
+	ContentSyncTimeoutEnv        = "CONTENT_SYNC_TIMEOUT"
+	DefaultContentSyncTimeout    = time.Minute
+
+	ExchangeRatesPathEnv           = "EXCHANGE_RATES_PATH"
+	DefaultExchangeRatesPath       = "rates.json"
+	ExchangeRatesReloadIntervalEnv = "EXCHANGE_RATES_RELOAD_INTERVAL"
+	DefaultExchangeRatesReload     = time.Minute
+
+	PricingRulesPathEnv     = "PRICING_RULES_PATH"
+	DefaultPricingRulesPath = "pricing.yaml"
+	AdminTokenEnv           = "ADMIN_TOKEN"
+
+	SuppliersEnv     = "SUPPLIERS"
+	DefaultSuppliers = "hotelbeds"
+
+	HotelMappingsPathEnv           = "HOTEL_MAPPINGS_PATH"
+	HotelMappingsReloadIntervalEnv = "HOTEL_MAPPINGS_RELOAD_INTERVAL"
+	DefaultHotelMappingsReload     = time.Minute
+	// HotelMappingsFromStore reads the hotel mappings imported into the content store rather than a file.
+	HotelMappingsFromStore = "store"
+
+	HotelbedsEnvironmentsEnv     = "HOTELBEDS_ENVIRONMENTS"
+	DefaultHotelbedsEnvironments = "test=https://api.test.hotelbeds.com,live=https://api.hotelbeds.com"
+	SupplierConfigApiKeysEnv     = "SUPPLIER_CONFIG_API_KEYS"
+	SupplierConfigAccountsEnv    = "SUPPLIER_CONFIG_ACCOUNTS"
+	SupplierConfigEnvsEnv        = "SUPPLIER_CONFIG_ENVIRONMENTS"
+
+	HotelbedsAccountsPathEnv     = "HOTELBEDS_ACCOUNTS_PATH"
+	DefaultHotelbedsAccountsPath = "accounts.yaml"
 )
 
 func BindEnv() {
@@ -22,8 +122,56 @@ func BindEnv() {
 	viper.SetDefault(AppPortEnv, DefaultAppPort)
 	viper.SetDefault(HotelbedsHostEnv, DefaultHotelbedsHost)
 	viper.SetDefault(AppModeEnv, DefaultAppMode)
+	viper.SetDefault(SearchBatchSizeEnv, DefaultSearchBatchSize)
+	viper.SetDefault(SearchConcurrencyEnv, DefaultSearchConcurrency)
+	viper.SetDefault(SearchCacheTTLEnv, DefaultSearchCacheTTL)
+	viper.SetDefault(SearchCacheMaxBytesEnv, DefaultSearchCacheMaxBytes)
+	viper.SetDefault(SearchCursorTTLEnv, DefaultSearchCursorTTL)
+	viper.SetDefault(SearchCursorMaxBytesEnv, DefaultSearchCursorMaxBytes)
+	viper.SetDefault(RetryMaxAttemptsEnv, DefaultRetryMaxAttempts)
+	viper.SetDefault(RetryBaseDelayEnv, DefaultRetryBaseDelay)
+	viper.SetDefault(RetryMaxDelayEnv, DefaultRetryMaxDelay)
+	viper.SetDefault(RetryJitterEnv, DefaultRetryJitter)
+	viper.SetDefault(RetryBudgetEnv, DefaultRetryBudget)
+	viper.SetDefault(CircuitFailureRateEnv, DefaultCircuitFailureRate)
+	viper.SetDefault(CircuitWindowEnv, DefaultCircuitWindow)
+	viper.SetDefault(CircuitMinRequestsEnv, DefaultCircuitMinRequests)
+	viper.SetDefault(CircuitCoolDownEnv, DefaultCircuitCoolDown)
+	viper.SetDefault(RateLimitPerSecondEnv, DefaultRateLimitPerSecond)
+	viper.SetDefault(RateLimitBurstEnv, DefaultRateLimitBurst)
+	viper.SetDefault(DailyQuotaEnv, DefaultDailyQuota)
+	viper.SetDefault(MaxIdleConnsEnv, DefaultMaxIdleConns)
+	viper.SetDefault(MaxIdleConnsPerHostEnv, DefaultMaxIdleConnsPerHost)
+	viper.SetDefault(MaxConnsPerHostEnv, DefaultMaxConnsPerHost)
+	viper.SetDefault(IdleConnTimeoutEnv, DefaultIdleConnTimeout)
+	viper.SetDefault(TLSHandshakeTimeoutEnv, DefaultTLSHandshakeTimeout)
+	viper.SetDefault(HotelbedsSearchTimeoutEnv, DefaultHotelbedsSearchTimeout)
+	viper.SetDefault(HotelbedsBookingTimeoutEnv, DefaultHotelbedsBookingTimeout)
+	viper.SetDefault(ContentStorePathEnv, DefaultContentStorePath)
+	viper.SetDefault(ContentReloadIntervalEnv, DefaultContentReloadInterval)
+	viper.SetDefault(ContentLanguageEnv, DefaultContentLanguage)
+	viper.SetDefault(ContentPageSizeEnv, DefaultContentPageSize)
+	viper.SetDefault(ContentSyncTimeoutEnv, DefaultContentSyncTimeout)
+	viper.SetDefault(ExchangeRatesPathEnv, DefaultExchangeRatesPath)
+	viper.SetDefault(ExchangeRatesReloadIntervalEnv, DefaultExchangeRatesReload)
+	viper.SetDefault(PricingRulesPathEnv, DefaultPricingRulesPath)
+	viper.SetDefault(SuppliersEnv, DefaultSuppliers)
+	viper.SetDefault(HotelMappingsReloadIntervalEnv, DefaultHotelMappingsReload)
+	viper.SetDefault(HotelbedsEnvironmentsEnv, DefaultHotelbedsEnvironments)
+	viper.SetDefault(HotelbedsAccountsPathEnv, DefaultHotelbedsAccountsPath)
 
-	for _, env := range []string{AppPortEnv, HotelbedsHostEnv, HotelbedsApiKeyEnv, HotelbedsSecretEnv} {
+	for _, env := range []string{AppPortEnv, HotelbedsHostEnv, HotelbedsApiKeyEnv, HotelbedsSecretEnv,
+		SearchBatchSizeEnv, SearchConcurrencyEnv, SearchCacheTTLEnv, SearchCacheMaxBytesEnv, SearchCursorTTLEnv,
+		SearchCursorMaxBytesEnv,
+		RetryMaxAttemptsEnv, RetryBaseDelayEnv, RetryMaxDelayEnv, RetryJitterEnv, RetryBudgetEnv,
+		CircuitFailureRateEnv, CircuitWindowEnv, CircuitMinRequestsEnv, CircuitCoolDownEnv,
+		RateLimitPerSecondEnv, RateLimitBurstEnv, DailyQuotaEnv,
+		MaxIdleConnsEnv, MaxIdleConnsPerHostEnv, MaxConnsPerHostEnv, IdleConnTimeoutEnv, TLSHandshakeTimeoutEnv,
+		HotelbedsProxyURLEnv, HotelbedsCABundleEnv, HotelbedsSearchTimeoutEnv, HotelbedsBookingTimeoutEnv,
+		ContentStorePathEnv, ContentReloadIntervalEnv, ContentLanguageEnv, ContentPageSizeEnv, ContentSyncTimeoutEnv,
+		ExchangeRatesPathEnv, ExchangeRatesReloadIntervalEnv, PricingRulesPathEnv, AdminTokenEnv, SuppliersEnv,
+		HotelMappingsPathEnv, HotelMappingsReloadIntervalEnv, HotelbedsEnvironmentsEnv, SupplierConfigApiKeysEnv,
+		HotelbedsAccountsPathEnv, SupplierConfigAccountsEnv, SupplierConfigEnvsEnv} {
 		_ = viper.BindEnv(env)
 	}
 }
@@ -17,6 +17,10 @@ func TestBindEnv(t *testing.T) {
 		require.Equal(t, DefaultAppPort, viper.GetString(AppPortEnv))
 		require.Equal(t, DefaultHotelbedsHost, viper.GetString(HotelbedsHostEnv))
 		require.Equal(t, DefaultAppMode, viper.GetString(AppModeEnv))
+		require.Equal(t, DefaultSearchBatchSize, viper.GetInt(SearchBatchSizeEnv))
+		require.Equal(t, DefaultSearchConcurrency, viper.GetInt(SearchConcurrencyEnv))
+		require.Equal(t, DefaultRetryMaxAttempts, viper.GetInt(RetryMaxAttemptsEnv))
+		require.Equal(t, DefaultRetryBaseDelay, viper.GetDuration(RetryBaseDelayEnv))
 	})
 
 	t.Run("Custom Environment Variables", func(t *testing.T) {
@@ -2,43 +2,217 @@ package cli
 
 import (
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-type StartFunc func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger)
+// Config is the application configuration read from command line flags or environment variables.
+type Config struct {
+	AppPort         string
+	AppMode         string
+	HotelbedsHost   string
+	HotelbedsApiKey string
+	HotelbedsSecret string
+	// SearchBatchSize is the maximum number of hotel ids sent to Hotelbeds in a single availability request.
+	SearchBatchSize int
+	// SearchConcurrency is the maximum number of availability requests in flight for a single search.
+	SearchConcurrency int
+	// SearchCacheTTL is how long availability responses are cached, zero disables the cache.
+	SearchCacheTTL time.Duration
+	// SearchCacheMaxBytes bounds the memory held by cached availability responses.
+	SearchCacheMaxBytes int
+	// SearchCursorTTL is how long the results of a paginated search can be browsed with its cursor.
+	SearchCursorTTL time.Duration
+	// SearchCursorMaxBytes bounds the memory held by the results of paginated searches.
+	SearchCursorMaxBytes int
+	// RetryMaxAttempts is the total number of attempts of a failed availability request, 1 disables retries.
+	RetryMaxAttempts int
+	// RetryBaseDelay is the backoff before the first retry, doubled for every further retry.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the backoff and the Retry-After delay lite-api is willing to wait.
+	RetryMaxDelay time.Duration
+	// RetryJitter is the fraction of the backoff which is randomised.
+	RetryJitter float64
+	// RetryBudget is the number of retries earned by every availability request.
+	RetryBudget float64
+	// CircuitFailureRate is the failure rate opening the Hotelbeds circuit breaker, zero disables the breaker.
+	CircuitFailureRate float64
+	// CircuitWindow is the number of most recent requests the failure rate is computed over.
+	CircuitWindow int
+	// CircuitMinRequests is the number of requests in the window below which the circuit never opens.
+	CircuitMinRequests int
+	// CircuitCoolDown is how long the circuit stays open before a probe request is let through.
+	CircuitCoolDown time.Duration
+	// RateLimitPerSecond is the sustained number of Hotelbeds requests per second, zero disables the limit.
+	RateLimitPerSecond float64
+	// RateLimitBurst is the number of Hotelbeds requests which may be sent at once after an idle period.
+	RateLimitBurst int
+	// DailyQuota is the number of Hotelbeds requests per UTC day, zero disables the limit.
+	DailyQuota int
+	// MaxIdleConns is the maximum number of idle connections kept in the Hotelbeds connection pool.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per Hotelbeds host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections per Hotelbeds host including those in use, zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle Hotelbeds connection stays in the pool.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout is the maximum time waited for a TLS handshake with Hotelbeds.
+	TLSHandshakeTimeout time.Duration
+	// HotelbedsProxyURL is the proxy Hotelbeds requests are sent through, the proxy environment variables are used when empty.
+	HotelbedsProxyURL string
+	// HotelbedsCABundle is the path of a PEM file of certificate authorities trusted on top of the system ones.
+	HotelbedsCABundle string
+	// HotelbedsSearchTimeout bounds every attempt of a Hotelbeds availability or rate check request.
+	HotelbedsSearchTimeout time.Duration
+	// HotelbedsBookingTimeout bounds Hotelbeds booking, booking detail and cancellation requests.
+	HotelbedsBookingTimeout time.Duration
+	// ContentStorePath is the file of the local content store written by the sync-content command.
+	ContentStorePath string
+	// ContentReloadInterval is how often the content store file is checked for changes.
+	ContentReloadInterval time.Duration
+	// ExchangeRatesPath is the JSON file of the exchange rates prices are converted with.
+	ExchangeRatesPath string
+	// ExchangeRatesReloadInterval is how often the exchange rates file is checked for changes.
+	ExchangeRatesReloadInterval time.Duration
+	// PricingRulesPath is the YAML or JSON file of the rules marking net prices up to selling prices.
+	PricingRulesPath string
+	// AdminToken is the bearer token of the admin endpoints, which are disabled when empty.
+	AdminToken string
+	// Suppliers are the names of the suppliers searched, in the order their results are merged.
+	Suppliers []string
+	// HotelMappingsPath is the CSV or JSON file of the hotel mappings, or HotelMappingsFromStore. Hotel ids are sent
+	// to suppliers as they are when empty.
+	HotelMappingsPath string
+	// HotelMappingsReloadInterval is how often the hotel mappings are checked for changes.
+	HotelMappingsReloadInterval time.Duration
+	// HotelbedsEnvironments maps the Hotelbeds environments to their host.
+	HotelbedsEnvironments map[string]string
+	// SupplierConfigApiKeys are the supplier API keys requests may send credentials of with the
+	// x-liteapi-supplier-config header, none may when empty.
+	SupplierConfigApiKeys []string
+	// HotelbedsAccountsPath is the YAML or JSON file of the Hotelbeds accounts requests may be signed with besides
+	// the one of HotelbedsApiKey and HotelbedsSecret.
+	HotelbedsAccountsPath string
+	// SupplierConfigAccounts are the Hotelbeds accounts requests may name with the x-liteapi-supplier-config
+	// header, none may when empty.
+	SupplierConfigAccounts []string
+	// SupplierConfigEnvironments are the Hotelbeds environments requests may pick with the x-liteapi-supplier-config
+	// header, none may when empty.
+	SupplierConfigEnvironments []string
+}
+
+type StartFunc func(cfg Config, logger *slog.Logger)
 
 func CreateStartCmdHandler(start StartFunc, logger *slog.Logger) (*cobra.Command, error) {
-	var (
-		appPort         string
-		appMode         string
-		hotelbedsHost   string
-		hotelbedsApiKey string
-		hotelbedsSecret string
-	)
+	var cfg Config
 
 	var startCmd = &cobra.Command{
 		Use:   "start",
 		Short: "Start lite-api application",
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get values from command line flags or environment variables
-			appPort = viper.GetString(AppPortEnv)
-			appMode = viper.GetString(AppModeEnv)
-			hotelbedsHost = viper.GetString(HotelbedsHostEnv)
-			hotelbedsApiKey = viper.GetString(HotelbedsApiKeyEnv)
-			hotelbedsSecret = viper.GetString(HotelbedsSecretEnv)
+			cfg.AppPort = viper.GetString(AppPortEnv)
+			cfg.AppMode = viper.GetString(AppModeEnv)
+			cfg.HotelbedsHost = viper.GetString(HotelbedsHostEnv)
+			cfg.HotelbedsApiKey = viper.GetString(HotelbedsApiKeyEnv)
+			cfg.HotelbedsSecret = viper.GetString(HotelbedsSecretEnv)
+			cfg.SearchBatchSize = viper.GetInt(SearchBatchSizeEnv)
+			cfg.SearchConcurrency = viper.GetInt(SearchConcurrencyEnv)
+			cfg.SearchCacheTTL = viper.GetDuration(SearchCacheTTLEnv)
+			cfg.SearchCacheMaxBytes = viper.GetInt(SearchCacheMaxBytesEnv)
+			cfg.SearchCursorTTL = viper.GetDuration(SearchCursorTTLEnv)
+			cfg.SearchCursorMaxBytes = viper.GetInt(SearchCursorMaxBytesEnv)
+			cfg.RetryMaxAttempts = viper.GetInt(RetryMaxAttemptsEnv)
+			cfg.RetryBaseDelay = viper.GetDuration(RetryBaseDelayEnv)
+			cfg.RetryMaxDelay = viper.GetDuration(RetryMaxDelayEnv)
+			cfg.RetryJitter = viper.GetFloat64(RetryJitterEnv)
+			cfg.RetryBudget = viper.GetFloat64(RetryBudgetEnv)
+			cfg.CircuitFailureRate = viper.GetFloat64(CircuitFailureRateEnv)
+			cfg.CircuitWindow = viper.GetInt(CircuitWindowEnv)
+			cfg.CircuitMinRequests = viper.GetInt(CircuitMinRequestsEnv)
+			cfg.CircuitCoolDown = viper.GetDuration(CircuitCoolDownEnv)
+			cfg.RateLimitPerSecond = viper.GetFloat64(RateLimitPerSecondEnv)
+			cfg.RateLimitBurst = viper.GetInt(RateLimitBurstEnv)
+			cfg.DailyQuota = viper.GetInt(DailyQuotaEnv)
+			cfg.MaxIdleConns = viper.GetInt(MaxIdleConnsEnv)
+			cfg.MaxIdleConnsPerHost = viper.GetInt(MaxIdleConnsPerHostEnv)
+			cfg.MaxConnsPerHost = viper.GetInt(MaxConnsPerHostEnv)
+			cfg.IdleConnTimeout = viper.GetDuration(IdleConnTimeoutEnv)
+			cfg.TLSHandshakeTimeout = viper.GetDuration(TLSHandshakeTimeoutEnv)
+			cfg.HotelbedsProxyURL = viper.GetString(HotelbedsProxyURLEnv)
+			cfg.HotelbedsCABundle = viper.GetString(HotelbedsCABundleEnv)
+			cfg.HotelbedsSearchTimeout = viper.GetDuration(HotelbedsSearchTimeoutEnv)
+			cfg.HotelbedsBookingTimeout = viper.GetDuration(HotelbedsBookingTimeoutEnv)
+			cfg.ContentStorePath = viper.GetString(ContentStorePathEnv)
+			cfg.ContentReloadInterval = viper.GetDuration(ContentReloadIntervalEnv)
+			cfg.ExchangeRatesPath = viper.GetString(ExchangeRatesPathEnv)
+			cfg.ExchangeRatesReloadInterval = viper.GetDuration(ExchangeRatesReloadIntervalEnv)
+			cfg.PricingRulesPath = viper.GetString(PricingRulesPathEnv)
+			cfg.AdminToken = viper.GetString(AdminTokenEnv)
+			cfg.Suppliers = splitList(viper.GetString(SuppliersEnv))
+			cfg.HotelMappingsPath = viper.GetString(HotelMappingsPathEnv)
+			cfg.HotelMappingsReloadInterval = viper.GetDuration(HotelMappingsReloadIntervalEnv)
+			cfg.HotelbedsEnvironments = splitPairs(viper.GetString(HotelbedsEnvironmentsEnv))
+			cfg.SupplierConfigApiKeys = splitList(viper.GetString(SupplierConfigApiKeysEnv))
+			cfg.HotelbedsAccountsPath = viper.GetString(HotelbedsAccountsPathEnv)
+			cfg.SupplierConfigAccounts = splitList(viper.GetString(SupplierConfigAccountsEnv))
+			cfg.SupplierConfigEnvironments = splitList(viper.GetString(SupplierConfigEnvsEnv))
 
-			start(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret, logger)
+			start(cfg, logger)
 		},
 	}
 
 	// Bind command line flags
-	startCmd.Flags().StringVarP(&appPort, "port", "p", DefaultAppPort, "Application port")
-	startCmd.Flags().StringVarP(&appMode, "mode", "m", DefaultAppMode, "Application mode")
-	startCmd.Flags().StringVarP(&hotelbedsHost, "host", "o", DefaultHotelbedsHost, "Hotelbeds API host")
-	startCmd.Flags().StringVarP(&hotelbedsApiKey, "apikey", "k", "", "Hotelbeds API key")
-	startCmd.Flags().StringVarP(&hotelbedsSecret, "secret", "s", "", "Hotelbeds API secret")
+	startCmd.Flags().StringVarP(&cfg.AppPort, "port", "p", DefaultAppPort, "Application port")
+	startCmd.Flags().StringVarP(&cfg.AppMode, "mode", "m", DefaultAppMode, "Application mode")
+	startCmd.Flags().StringVarP(&cfg.HotelbedsHost, "host", "o", DefaultHotelbedsHost, "Hotelbeds API host")
+	startCmd.Flags().StringVarP(&cfg.HotelbedsApiKey, "apikey", "k", "", "Hotelbeds API key")
+	startCmd.Flags().StringVarP(&cfg.HotelbedsSecret, "secret", "s", "", "Hotelbeds API secret")
+	startCmd.Flags().IntVar(&cfg.SearchBatchSize, "batch-size", DefaultSearchBatchSize, "Maximum hotel ids per Hotelbeds availability request")
+	startCmd.Flags().IntVar(&cfg.SearchConcurrency, "concurrency", DefaultSearchConcurrency, "Maximum concurrent Hotelbeds availability requests per search")
+	startCmd.Flags().DurationVar(&cfg.SearchCacheTTL, "cache-ttl", DefaultSearchCacheTTL, "Availability cache TTL, 0 disables the cache")
+	startCmd.Flags().IntVar(&cfg.SearchCacheMaxBytes, "cache-max-bytes", DefaultSearchCacheMaxBytes, "Maximum memory held by the availability cache")
+	startCmd.Flags().DurationVar(&cfg.SearchCursorTTL, "cursor-ttl", DefaultSearchCursorTTL, "How long the results of a paginated search can be browsed with its cursor")
+	startCmd.Flags().IntVar(&cfg.SearchCursorMaxBytes, "cursor-max-bytes", DefaultSearchCursorMaxBytes, "Maximum memory held by the results of paginated searches")
+	startCmd.Flags().IntVar(&cfg.RetryMaxAttempts, "retry-max-attempts", DefaultRetryMaxAttempts, "Maximum attempts of a failed Hotelbeds availability request, 1 disables retries")
+	startCmd.Flags().DurationVar(&cfg.RetryBaseDelay, "retry-base-delay", DefaultRetryBaseDelay, "Backoff before the first retry, doubled for every further retry")
+	startCmd.Flags().DurationVar(&cfg.RetryMaxDelay, "retry-max-delay", DefaultRetryMaxDelay, "Maximum backoff or Retry-After delay waited before a retry")
+	startCmd.Flags().Float64Var(&cfg.RetryJitter, "retry-jitter", DefaultRetryJitter, "Fraction of the backoff which is randomised")
+	startCmd.Flags().Float64Var(&cfg.RetryBudget, "retry-budget", DefaultRetryBudget, "Retries earned by every availability request, 0 disables the budget")
+	startCmd.Flags().Float64Var(&cfg.CircuitFailureRate, "circuit-failure-rate", DefaultCircuitFailureRate, "Failure rate opening the Hotelbeds circuit breaker, 0 disables the breaker")
+	startCmd.Flags().IntVar(&cfg.CircuitWindow, "circuit-window", DefaultCircuitWindow, "Number of most recent Hotelbeds requests the failure rate is computed over")
+	startCmd.Flags().IntVar(&cfg.CircuitMinRequests, "circuit-min-requests", DefaultCircuitMinRequests, "Number of requests in the window below which the circuit never opens")
+	startCmd.Flags().DurationVar(&cfg.CircuitCoolDown, "circuit-cool-down", DefaultCircuitCoolDown, "How long the circuit stays open before a probe request is let through")
+	startCmd.Flags().Float64Var(&cfg.RateLimitPerSecond, "rate-limit", DefaultRateLimitPerSecond, "Sustained Hotelbeds requests per second, 0 disables the limit")
+	startCmd.Flags().IntVar(&cfg.RateLimitBurst, "rate-burst", DefaultRateLimitBurst, "Hotelbeds requests which may be sent at once after an idle period")
+	startCmd.Flags().IntVar(&cfg.DailyQuota, "daily-quota", DefaultDailyQuota, "Hotelbeds requests per UTC day, 0 disables the limit")
+	startCmd.Flags().IntVar(&cfg.MaxIdleConns, "max-idle-conns", DefaultMaxIdleConns, "Maximum idle connections kept in the Hotelbeds connection pool")
+	startCmd.Flags().IntVar(&cfg.MaxIdleConnsPerHost, "max-idle-conns-per-host", DefaultMaxIdleConnsPerHost, "Maximum idle connections kept per Hotelbeds host")
+	startCmd.Flags().IntVar(&cfg.MaxConnsPerHost, "max-conns-per-host", DefaultMaxConnsPerHost, "Maximum connections per Hotelbeds host, 0 means no limit")
+	startCmd.Flags().DurationVar(&cfg.IdleConnTimeout, "idle-conn-timeout", DefaultIdleConnTimeout, "How long an idle Hotelbeds connection stays in the pool")
+	startCmd.Flags().DurationVar(&cfg.TLSHandshakeTimeout, "tls-handshake-timeout", DefaultTLSHandshakeTimeout, "Maximum time waited for a TLS handshake with Hotelbeds")
+	startCmd.Flags().StringVar(&cfg.HotelbedsProxyURL, "proxy-url", "", "Proxy Hotelbeds requests are sent through, the proxy environment variables are used when empty")
+	startCmd.Flags().StringVar(&cfg.HotelbedsCABundle, "ca-bundle", "", "PEM file of certificate authorities trusted on top of the system ones")
+	startCmd.Flags().DurationVar(&cfg.HotelbedsSearchTimeout, "search-timeout", DefaultHotelbedsSearchTimeout, "Timeout of a Hotelbeds availability or rate check request")
+	startCmd.Flags().DurationVar(&cfg.HotelbedsBookingTimeout, "booking-timeout", DefaultHotelbedsBookingTimeout, "Timeout of a Hotelbeds booking, booking detail or cancellation request")
+	startCmd.Flags().StringVar(&cfg.ContentStorePath, "content-store", DefaultContentStorePath, "File of the local content store written by sync-content")
+	startCmd.Flags().DurationVar(&cfg.ContentReloadInterval, "content-reload-interval", DefaultContentReloadInterval, "How often the content store file is checked for changes")
+	startCmd.Flags().StringVar(&cfg.ExchangeRatesPath, "rates-file", DefaultExchangeRatesPath, "JSON file of the exchange rates prices are converted with")
+	startCmd.Flags().DurationVar(&cfg.ExchangeRatesReloadInterval, "rates-reload-interval", DefaultExchangeRatesReload, "How often the exchange rates file is checked for changes")
+	startCmd.Flags().StringVar(&cfg.PricingRulesPath, "pricing-rules", DefaultPricingRulesPath, "YAML or JSON file of the rules marking net prices up to selling prices")
+	startCmd.Flags().StringVar(&cfg.AdminToken, "admin-token", "", "Bearer token of the admin endpoints, which are disabled when empty")
+	startCmd.Flags().String("suppliers", DefaultSuppliers, "Comma separated names of the suppliers searched, in the order their results are merged")
+	startCmd.Flags().StringVar(&cfg.HotelMappingsPath, "hotel-mappings", "", "CSV or JSON file of the hotel id mappings, or store for those imported into the content store")
+	startCmd.Flags().DurationVar(&cfg.HotelMappingsReloadInterval, "hotel-mappings-reload-interval", DefaultHotelMappingsReload, "How often the hotel mappings are checked for changes")
+	startCmd.Flags().String("environments", DefaultHotelbedsEnvironments, "Comma separated name=host pairs of the Hotelbeds environments")
+	startCmd.Flags().String("supplier-config-api-keys", "", "Comma separated supplier API keys requests may send credentials of with the x-liteapi-supplier-config header")
+	startCmd.Flags().StringVar(&cfg.HotelbedsAccountsPath, "accounts", DefaultHotelbedsAccountsPath, "YAML or JSON file of the Hotelbeds accounts and keys requests are signed with, by market or by name")
+	startCmd.Flags().String("supplier-config-accounts", "", "Comma separated Hotelbeds accounts requests may name with the x-liteapi-supplier-config header")
+	startCmd.Flags().String("supplier-config-environments", "", "Comma separated Hotelbeds environments requests may pick with the x-liteapi-supplier-config header")
 
 	// Bind flags with viper
 	if err := viper.BindPFlag(AppPortEnv, startCmd.Flags().Lookup("port")); err != nil {
@@ -61,5 +235,194 @@ func CreateStartCmdHandler(start StartFunc, logger *slog.Logger) (*cobra.Command
 		return nil, err
 	}
 
+	if err := viper.BindPFlag(SearchBatchSizeEnv, startCmd.Flags().Lookup("batch-size")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SearchConcurrencyEnv, startCmd.Flags().Lookup("concurrency")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SearchCacheTTLEnv, startCmd.Flags().Lookup("cache-ttl")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SearchCacheMaxBytesEnv, startCmd.Flags().Lookup("cache-max-bytes")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SearchCursorTTLEnv, startCmd.Flags().Lookup("cursor-ttl")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SearchCursorMaxBytesEnv, startCmd.Flags().Lookup("cursor-max-bytes")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RetryMaxAttemptsEnv, startCmd.Flags().Lookup("retry-max-attempts")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RetryBaseDelayEnv, startCmd.Flags().Lookup("retry-base-delay")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RetryMaxDelayEnv, startCmd.Flags().Lookup("retry-max-delay")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RetryJitterEnv, startCmd.Flags().Lookup("retry-jitter")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RetryBudgetEnv, startCmd.Flags().Lookup("retry-budget")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(CircuitFailureRateEnv, startCmd.Flags().Lookup("circuit-failure-rate")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(CircuitWindowEnv, startCmd.Flags().Lookup("circuit-window")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(CircuitMinRequestsEnv, startCmd.Flags().Lookup("circuit-min-requests")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(CircuitCoolDownEnv, startCmd.Flags().Lookup("circuit-cool-down")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RateLimitPerSecondEnv, startCmd.Flags().Lookup("rate-limit")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(RateLimitBurstEnv, startCmd.Flags().Lookup("rate-burst")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(DailyQuotaEnv, startCmd.Flags().Lookup("daily-quota")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(MaxIdleConnsEnv, startCmd.Flags().Lookup("max-idle-conns")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(MaxIdleConnsPerHostEnv, startCmd.Flags().Lookup("max-idle-conns-per-host")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(MaxConnsPerHostEnv, startCmd.Flags().Lookup("max-conns-per-host")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(IdleConnTimeoutEnv, startCmd.Flags().Lookup("idle-conn-timeout")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(TLSHandshakeTimeoutEnv, startCmd.Flags().Lookup("tls-handshake-timeout")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsProxyURLEnv, startCmd.Flags().Lookup("proxy-url")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsCABundleEnv, startCmd.Flags().Lookup("ca-bundle")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsSearchTimeoutEnv, startCmd.Flags().Lookup("search-timeout")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsBookingTimeoutEnv, startCmd.Flags().Lookup("booking-timeout")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(ContentStorePathEnv, startCmd.Flags().Lookup("content-store")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(ContentReloadIntervalEnv, startCmd.Flags().Lookup("content-reload-interval")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(ExchangeRatesPathEnv, startCmd.Flags().Lookup("rates-file")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(ExchangeRatesReloadIntervalEnv, startCmd.Flags().Lookup("rates-reload-interval")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(PricingRulesPathEnv, startCmd.Flags().Lookup("pricing-rules")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(AdminTokenEnv, startCmd.Flags().Lookup("admin-token")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SuppliersEnv, startCmd.Flags().Lookup("suppliers")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelMappingsPathEnv, startCmd.Flags().Lookup("hotel-mappings")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelMappingsReloadIntervalEnv, startCmd.Flags().Lookup("hotel-mappings-reload-interval")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsEnvironmentsEnv, startCmd.Flags().Lookup("environments")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SupplierConfigApiKeysEnv, startCmd.Flags().Lookup("supplier-config-api-keys")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(HotelbedsAccountsPathEnv, startCmd.Flags().Lookup("accounts")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SupplierConfigAccountsEnv, startCmd.Flags().Lookup("supplier-config-accounts")); err != nil {
+		return nil, err
+	}
+
+	if err := viper.BindPFlag(SupplierConfigEnvsEnv, startCmd.Flags().Lookup("supplier-config-environments")); err != nil {
+		return nil, err
+	}
+
 	return startCmd, nil
 }
+
+// splitList returns the trimmed non-empty items of the comma separated list s.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// splitPairs returns the name=value pairs of the comma separated list s, items without a name or value are skipped.
+func splitPairs(s string) map[string]string {
+	pairs := make(map[string]string)
+	for _, item := range splitList(s) {
+		name, value, _ := strings.Cut(item, "=")
+		if name, value = strings.TrimSpace(name), strings.TrimSpace(value); name != "" && value != "" {
+			pairs[name] = value
+		}
+	}
+
+	return pairs
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -12,7 +13,7 @@ import (
 
 func TestCreateStartCmdHandler(t *testing.T) {
 	t.Run("Successful command creation", func(t *testing.T) {
-		mockStart := func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {}
+		mockStart := func(cfg Config, logger *slog.Logger) {}
 		cmd, err := CreateStartCmdHandler(mockStart, nil)
 
 		require.NoError(t, err)
@@ -22,7 +23,7 @@ func TestCreateStartCmdHandler(t *testing.T) {
 	})
 
 	t.Run("Flag bindings", func(t *testing.T) {
-		mockStart := func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {}
+		mockStart := func(cfg Config, logger *slog.Logger) {}
 		cmd, err := CreateStartCmdHandler(mockStart, nil)
 
 		require.NoError(t, err)
@@ -33,11 +34,52 @@ func TestCreateStartCmdHandler(t *testing.T) {
 		require.NotNil(t, cmd.Flags().Lookup("host"))
 		require.NotNil(t, cmd.Flags().Lookup("apikey"))
 		require.NotNil(t, cmd.Flags().Lookup("secret"))
+		require.NotNil(t, cmd.Flags().Lookup("batch-size"))
+		require.NotNil(t, cmd.Flags().Lookup("concurrency"))
+		require.NotNil(t, cmd.Flags().Lookup("cache-ttl"))
+		require.NotNil(t, cmd.Flags().Lookup("cache-max-bytes"))
+		require.NotNil(t, cmd.Flags().Lookup("cursor-ttl"))
+		require.NotNil(t, cmd.Flags().Lookup("cursor-max-bytes"))
+		require.NotNil(t, cmd.Flags().Lookup("retry-max-attempts"))
+		require.NotNil(t, cmd.Flags().Lookup("retry-base-delay"))
+		require.NotNil(t, cmd.Flags().Lookup("retry-max-delay"))
+		require.NotNil(t, cmd.Flags().Lookup("retry-jitter"))
+		require.NotNil(t, cmd.Flags().Lookup("retry-budget"))
+		require.NotNil(t, cmd.Flags().Lookup("circuit-failure-rate"))
+		require.NotNil(t, cmd.Flags().Lookup("circuit-window"))
+		require.NotNil(t, cmd.Flags().Lookup("circuit-min-requests"))
+		require.NotNil(t, cmd.Flags().Lookup("circuit-cool-down"))
+		require.NotNil(t, cmd.Flags().Lookup("rate-limit"))
+		require.NotNil(t, cmd.Flags().Lookup("rate-burst"))
+		require.NotNil(t, cmd.Flags().Lookup("daily-quota"))
+		require.NotNil(t, cmd.Flags().Lookup("max-idle-conns"))
+		require.NotNil(t, cmd.Flags().Lookup("max-idle-conns-per-host"))
+		require.NotNil(t, cmd.Flags().Lookup("max-conns-per-host"))
+		require.NotNil(t, cmd.Flags().Lookup("idle-conn-timeout"))
+		require.NotNil(t, cmd.Flags().Lookup("tls-handshake-timeout"))
+		require.NotNil(t, cmd.Flags().Lookup("proxy-url"))
+		require.NotNil(t, cmd.Flags().Lookup("ca-bundle"))
+		require.NotNil(t, cmd.Flags().Lookup("search-timeout"))
+		require.NotNil(t, cmd.Flags().Lookup("booking-timeout"))
+		require.NotNil(t, cmd.Flags().Lookup("content-store"))
+		require.NotNil(t, cmd.Flags().Lookup("content-reload-interval"))
+		require.NotNil(t, cmd.Flags().Lookup("rates-file"))
+		require.NotNil(t, cmd.Flags().Lookup("rates-reload-interval"))
+		require.NotNil(t, cmd.Flags().Lookup("pricing-rules"))
+		require.NotNil(t, cmd.Flags().Lookup("admin-token"))
+		require.NotNil(t, cmd.Flags().Lookup("suppliers"))
+		require.NotNil(t, cmd.Flags().Lookup("hotel-mappings"))
+		require.NotNil(t, cmd.Flags().Lookup("hotel-mappings-reload-interval"))
+		require.NotNil(t, cmd.Flags().Lookup("environments"))
+		require.NotNil(t, cmd.Flags().Lookup("supplier-config-api-keys"))
+		require.NotNil(t, cmd.Flags().Lookup("accounts"))
+		require.NotNil(t, cmd.Flags().Lookup("supplier-config-accounts"))
+		require.NotNil(t, cmd.Flags().Lookup("supplier-config-environments"))
 	})
 
 	t.Run("Viper bindings", func(t *testing.T) {
 		viper.Reset()
-		mockStart := func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {}
+		mockStart := func(cfg Config, logger *slog.Logger) {}
 		cmd, err := CreateStartCmdHandler(mockStart, nil)
 
 		require.NoError(t, err)
@@ -52,13 +94,57 @@ func TestCreateStartCmdHandler(t *testing.T) {
 
 	t.Run("Command execution", func(t *testing.T) {
 		var executedStart bool
-		mockStart := func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {
+		mockStart := func(cfg Config, logger *slog.Logger) {
 			executedStart = true
-			require.Equal(t, DefaultAppPort, appPort)
-			require.Equal(t, DefaultAppMode, appMode)
-			require.Equal(t, DefaultHotelbedsHost, hotelbedsHost)
-			require.Empty(t, hotelbedsApiKey)
-			require.Empty(t, hotelbedsSecret)
+			require.Equal(t, DefaultAppPort, cfg.AppPort)
+			require.Equal(t, DefaultAppMode, cfg.AppMode)
+			require.Equal(t, DefaultHotelbedsHost, cfg.HotelbedsHost)
+			require.Empty(t, cfg.HotelbedsApiKey)
+			require.Empty(t, cfg.HotelbedsSecret)
+			require.Equal(t, DefaultSearchBatchSize, cfg.SearchBatchSize)
+			require.Equal(t, DefaultSearchConcurrency, cfg.SearchConcurrency)
+			require.Equal(t, DefaultSearchCacheTTL, cfg.SearchCacheTTL)
+			require.Equal(t, DefaultSearchCacheMaxBytes, cfg.SearchCacheMaxBytes)
+			require.Equal(t, DefaultSearchCursorTTL, cfg.SearchCursorTTL)
+			require.Equal(t, DefaultSearchCursorMaxBytes, cfg.SearchCursorMaxBytes)
+			require.Equal(t, DefaultRetryMaxAttempts, cfg.RetryMaxAttempts)
+			require.Equal(t, DefaultRetryBaseDelay, cfg.RetryBaseDelay)
+			require.Equal(t, DefaultRetryMaxDelay, cfg.RetryMaxDelay)
+			require.Equal(t, DefaultRetryJitter, cfg.RetryJitter)
+			require.Equal(t, DefaultRetryBudget, cfg.RetryBudget)
+			require.Equal(t, DefaultCircuitFailureRate, cfg.CircuitFailureRate)
+			require.Equal(t, DefaultCircuitWindow, cfg.CircuitWindow)
+			require.Equal(t, DefaultCircuitMinRequests, cfg.CircuitMinRequests)
+			require.Equal(t, DefaultCircuitCoolDown, cfg.CircuitCoolDown)
+			require.Equal(t, DefaultRateLimitPerSecond, cfg.RateLimitPerSecond)
+			require.Equal(t, DefaultRateLimitBurst, cfg.RateLimitBurst)
+			require.Equal(t, DefaultDailyQuota, cfg.DailyQuota)
+			require.Equal(t, DefaultMaxIdleConns, cfg.MaxIdleConns)
+			require.Equal(t, DefaultMaxIdleConnsPerHost, cfg.MaxIdleConnsPerHost)
+			require.Equal(t, DefaultMaxConnsPerHost, cfg.MaxConnsPerHost)
+			require.Equal(t, DefaultIdleConnTimeout, cfg.IdleConnTimeout)
+			require.Equal(t, DefaultTLSHandshakeTimeout, cfg.TLSHandshakeTimeout)
+			require.Empty(t, cfg.HotelbedsProxyURL)
+			require.Empty(t, cfg.HotelbedsCABundle)
+			require.Equal(t, DefaultHotelbedsSearchTimeout, cfg.HotelbedsSearchTimeout)
+			require.Equal(t, DefaultHotelbedsBookingTimeout, cfg.HotelbedsBookingTimeout)
+			require.Equal(t, DefaultContentStorePath, cfg.ContentStorePath)
+			require.Equal(t, DefaultContentReloadInterval, cfg.ContentReloadInterval)
+			require.Equal(t, DefaultExchangeRatesPath, cfg.ExchangeRatesPath)
+			require.Equal(t, DefaultExchangeRatesReload, cfg.ExchangeRatesReloadInterval)
+			require.Equal(t, DefaultPricingRulesPath, cfg.PricingRulesPath)
+			require.Empty(t, cfg.AdminToken)
+			require.Equal(t, []string{"hotelbeds"}, cfg.Suppliers)
+			require.Empty(t, cfg.HotelMappingsPath)
+			require.Equal(t, DefaultHotelMappingsReload, cfg.HotelMappingsReloadInterval)
+			require.Equal(t, map[string]string{
+				"test": "https://api.test.hotelbeds.com",
+				"live": "https://api.hotelbeds.com",
+			}, cfg.HotelbedsEnvironments)
+			require.Empty(t, cfg.SupplierConfigApiKeys)
+			require.Equal(t, DefaultHotelbedsAccountsPath, cfg.HotelbedsAccountsPath)
+			require.Empty(t, cfg.SupplierConfigAccounts)
+			require.Empty(t, cfg.SupplierConfigEnvironments)
 		}
 
 		cmd, err := CreateStartCmdHandler(mockStart, nil)
@@ -72,13 +158,54 @@ func TestCreateStartCmdHandler(t *testing.T) {
 
 	t.Run("Command execution with flags", func(t *testing.T) {
 		var executedStart bool
-		mockStart := func(appPort, appMode, hotelbedsHost, hotelbedsApiKey, hotelbedsSecret string, logger *slog.Logger) {
+		mockStart := func(cfg Config, logger *slog.Logger) {
 			executedStart = true
-			require.Equal(t, "8080", appPort)
-			require.Equal(t, "test", appMode)
-			require.Equal(t, "testhost", hotelbedsHost)
-			require.Equal(t, "testkey", hotelbedsApiKey)
-			require.Equal(t, "testsecret", hotelbedsSecret)
+			require.Equal(t, "8080", cfg.AppPort)
+			require.Equal(t, "test", cfg.AppMode)
+			require.Equal(t, "testhost", cfg.HotelbedsHost)
+			require.Equal(t, "testkey", cfg.HotelbedsApiKey)
+			require.Equal(t, "testsecret", cfg.HotelbedsSecret)
+			require.Equal(t, 50, cfg.SearchBatchSize)
+			require.Equal(t, 8, cfg.SearchConcurrency)
+			require.Equal(t, 30*time.Second, cfg.SearchCacheTTL)
+			require.Equal(t, 1024, cfg.SearchCacheMaxBytes)
+			require.Equal(t, 5*time.Minute, cfg.SearchCursorTTL)
+			require.Equal(t, 2048, cfg.SearchCursorMaxBytes)
+			require.Equal(t, 5, cfg.RetryMaxAttempts)
+			require.Equal(t, 50*time.Millisecond, cfg.RetryBaseDelay)
+			require.Equal(t, time.Second, cfg.RetryMaxDelay)
+			require.Equal(t, 0.25, cfg.RetryJitter)
+			require.Equal(t, 0.2, cfg.RetryBudget)
+			require.Equal(t, 0.75, cfg.CircuitFailureRate)
+			require.Equal(t, 40, cfg.CircuitWindow)
+			require.Equal(t, 20, cfg.CircuitMinRequests)
+			require.Equal(t, time.Minute, cfg.CircuitCoolDown)
+			require.Equal(t, 8.0, cfg.RateLimitPerSecond)
+			require.Equal(t, 4, cfg.RateLimitBurst)
+			require.Equal(t, 50000, cfg.DailyQuota)
+			require.Equal(t, 200, cfg.MaxIdleConns)
+			require.Equal(t, 50, cfg.MaxIdleConnsPerHost)
+			require.Equal(t, 64, cfg.MaxConnsPerHost)
+			require.Equal(t, 2*time.Minute, cfg.IdleConnTimeout)
+			require.Equal(t, 5*time.Second, cfg.TLSHandshakeTimeout)
+			require.Equal(t, "http://proxy.internal:3128", cfg.HotelbedsProxyURL)
+			require.Equal(t, "/etc/ssl/hotelbeds.pem", cfg.HotelbedsCABundle)
+			require.Equal(t, 8*time.Second, cfg.HotelbedsSearchTimeout)
+			require.Equal(t, 45*time.Second, cfg.HotelbedsBookingTimeout)
+			require.Equal(t, "/var/lib/lite-api/content.db", cfg.ContentStorePath)
+			require.Equal(t, 5*time.Minute, cfg.ContentReloadInterval)
+			require.Equal(t, "/tmp/rates.json", cfg.ExchangeRatesPath)
+			require.Equal(t, 30*time.Second, cfg.ExchangeRatesReloadInterval)
+			require.Equal(t, "/tmp/pricing.json", cfg.PricingRulesPath)
+			require.Equal(t, "secret-admin-token", cfg.AdminToken)
+			require.Equal(t, []string{"hotelbeds", "other"}, cfg.Suppliers)
+			require.Equal(t, "mappings.csv", cfg.HotelMappingsPath)
+			require.Equal(t, 5*time.Minute, cfg.HotelMappingsReloadInterval)
+			require.Equal(t, map[string]string{"live": "https://api.hotelbeds.com"}, cfg.HotelbedsEnvironments)
+			require.Equal(t, []string{"key-a", "key-b"}, cfg.SupplierConfigApiKeys)
+			require.Equal(t, "accounts.json", cfg.HotelbedsAccountsPath)
+			require.Equal(t, []string{"eu", "us"}, cfg.SupplierConfigAccounts)
+			require.Equal(t, []string{"test"}, cfg.SupplierConfigEnvironments)
 		}
 
 		cmd, err := CreateStartCmdHandler(mockStart, nil)
@@ -90,6 +217,47 @@ func TestCreateStartCmdHandler(t *testing.T) {
 		require.NoError(t, cmd.Flags().Set("host", "testhost"))
 		require.NoError(t, cmd.Flags().Set("apikey", "testkey"))
 		require.NoError(t, cmd.Flags().Set("secret", "testsecret"))
+		require.NoError(t, cmd.Flags().Set("batch-size", "50"))
+		require.NoError(t, cmd.Flags().Set("concurrency", "8"))
+		require.NoError(t, cmd.Flags().Set("cache-ttl", "30s"))
+		require.NoError(t, cmd.Flags().Set("cache-max-bytes", "1024"))
+		require.NoError(t, cmd.Flags().Set("cursor-ttl", "5m"))
+		require.NoError(t, cmd.Flags().Set("cursor-max-bytes", "2048"))
+		require.NoError(t, cmd.Flags().Set("retry-max-attempts", "5"))
+		require.NoError(t, cmd.Flags().Set("retry-base-delay", "50ms"))
+		require.NoError(t, cmd.Flags().Set("retry-max-delay", "1s"))
+		require.NoError(t, cmd.Flags().Set("retry-jitter", "0.25"))
+		require.NoError(t, cmd.Flags().Set("retry-budget", "0.2"))
+		require.NoError(t, cmd.Flags().Set("circuit-failure-rate", "0.75"))
+		require.NoError(t, cmd.Flags().Set("circuit-window", "40"))
+		require.NoError(t, cmd.Flags().Set("circuit-min-requests", "20"))
+		require.NoError(t, cmd.Flags().Set("circuit-cool-down", "1m"))
+		require.NoError(t, cmd.Flags().Set("rate-limit", "8"))
+		require.NoError(t, cmd.Flags().Set("rate-burst", "4"))
+		require.NoError(t, cmd.Flags().Set("daily-quota", "50000"))
+		require.NoError(t, cmd.Flags().Set("max-idle-conns", "200"))
+		require.NoError(t, cmd.Flags().Set("max-idle-conns-per-host", "50"))
+		require.NoError(t, cmd.Flags().Set("max-conns-per-host", "64"))
+		require.NoError(t, cmd.Flags().Set("idle-conn-timeout", "2m"))
+		require.NoError(t, cmd.Flags().Set("tls-handshake-timeout", "5s"))
+		require.NoError(t, cmd.Flags().Set("proxy-url", "http://proxy.internal:3128"))
+		require.NoError(t, cmd.Flags().Set("ca-bundle", "/etc/ssl/hotelbeds.pem"))
+		require.NoError(t, cmd.Flags().Set("search-timeout", "8s"))
+		require.NoError(t, cmd.Flags().Set("booking-timeout", "45s"))
+		require.NoError(t, cmd.Flags().Set("content-store", "/var/lib/lite-api/content.db"))
+		require.NoError(t, cmd.Flags().Set("content-reload-interval", "5m"))
+		require.NoError(t, cmd.Flags().Set("rates-file", "/tmp/rates.json"))
+		require.NoError(t, cmd.Flags().Set("rates-reload-interval", "30s"))
+		require.NoError(t, cmd.Flags().Set("pricing-rules", "/tmp/pricing.json"))
+		require.NoError(t, cmd.Flags().Set("admin-token", "secret-admin-token"))
+		require.NoError(t, cmd.Flags().Set("suppliers", "hotelbeds, other,"))
+		require.NoError(t, cmd.Flags().Set("hotel-mappings", "mappings.csv"))
+		require.NoError(t, cmd.Flags().Set("hotel-mappings-reload-interval", "5m"))
+		require.NoError(t, cmd.Flags().Set("environments", "live=https://api.hotelbeds.com,broken"))
+		require.NoError(t, cmd.Flags().Set("supplier-config-api-keys", "key-a,key-b"))
+		require.NoError(t, cmd.Flags().Set("accounts", "accounts.json"))
+		require.NoError(t, cmd.Flags().Set("supplier-config-accounts", "eu,us"))
+		require.NoError(t, cmd.Flags().Set("supplier-config-environments", "test"))
 
 		require.NoError(t, cmd.Execute())
 
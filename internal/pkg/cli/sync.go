@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// SyncConfig is the sync-content command configuration read from command line flags or environment variables.
+type SyncConfig struct {
+	HotelbedsHost   string
+	HotelbedsApiKey string
+	HotelbedsSecret string
+	// StorePath is the file of the local content store.
+	StorePath string
+	// Language is the three letter Hotelbeds language code of the descriptions.
+	Language string
+	// PageSize is the number of items fetched per Content API request.
+	PageSize int
+	// Timeout bounds every Content API request.
+	Timeout time.Duration
+	// Full fetches every item instead of only those updated since the last sync.
+	Full bool
+	// MappingsPath is the CSV or JSON file of hotel mappings imported into the store, replacing the previous ones.
+	MappingsPath string
+}
+
+type SyncFunc func(cfg SyncConfig, logger *slog.Logger) error
+
+func CreateSyncContentCmdHandler(sync SyncFunc, logger *slog.Logger) *cobra.Command {
+	var cfg SyncConfig
+
+	var syncCmd = &cobra.Command{
+		Use:   "sync-content",
+		Short: "Sync Hotelbeds static content into the local content store",
+		// Flags are bound when the command runs, as they share their keys with the start command flags.
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for env, flag := range map[string]string{
+				HotelbedsHostEnv:      "host",
+				HotelbedsApiKeyEnv:    "apikey",
+				HotelbedsSecretEnv:    "secret",
+				ContentStorePathEnv:   "store",
+				ContentLanguageEnv:    "language",
+				ContentPageSizeEnv:    "page-size",
+				ContentSyncTimeoutEnv: "timeout",
+			} {
+				if err := viper.BindPFlag(env, cmd.Flags().Lookup(flag)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Get values from command line flags or environment variables
+			cfg.HotelbedsHost = viper.GetString(HotelbedsHostEnv)
+			cfg.HotelbedsApiKey = viper.GetString(HotelbedsApiKeyEnv)
+			cfg.HotelbedsSecret = viper.GetString(HotelbedsSecretEnv)
+			cfg.StorePath = viper.GetString(ContentStorePathEnv)
+			cfg.Language = viper.GetString(ContentLanguageEnv)
+			cfg.PageSize = viper.GetInt(ContentPageSizeEnv)
+			cfg.Timeout = viper.GetDuration(ContentSyncTimeoutEnv)
+
+			return sync(cfg, logger)
+		},
+	}
+
+	syncCmd.Flags().StringVarP(&cfg.HotelbedsHost, "host", "o", DefaultHotelbedsHost, "Hotelbeds API host")
+	syncCmd.Flags().StringVarP(&cfg.HotelbedsApiKey, "apikey", "k", "", "Hotelbeds API key")
+	syncCmd.Flags().StringVarP(&cfg.HotelbedsSecret, "secret", "s", "", "Hotelbeds API secret")
+	syncCmd.Flags().StringVar(&cfg.StorePath, "store", DefaultContentStorePath, "File of the local content store")
+	syncCmd.Flags().StringVar(&cfg.Language, "language", DefaultContentLanguage, "Hotelbeds language code of the descriptions")
+	syncCmd.Flags().IntVar(&cfg.PageSize, "page-size", DefaultContentPageSize, "Items fetched per Content API request, at most 1000")
+	syncCmd.Flags().DurationVar(&cfg.Timeout, "timeout", DefaultContentSyncTimeout, "Timeout of a Content API request")
+	syncCmd.Flags().BoolVar(&cfg.Full, "full", false, "Fetch every item instead of only those updated since the last sync")
+	syncCmd.Flags().StringVar(&cfg.MappingsPath, "mappings", "", "CSV or JSON file of hotel id mappings imported into the store, replacing the previous ones")
+
+	return syncCmd
+}
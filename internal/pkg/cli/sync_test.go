@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSyncContentCmdHandler(t *testing.T) {
+	t.Run("Successful command creation", func(t *testing.T) {
+		cmd := CreateSyncContentCmdHandler(func(cfg SyncConfig, logger *slog.Logger) error { return nil }, nil)
+
+		require.NotNil(t, cmd)
+		require.Equal(t, "sync-content", cmd.Use)
+		for _, flag := range []string{"host", "apikey", "secret", "store", "language", "page-size", "timeout", "full", "mappings"} {
+			require.NotNil(t, cmd.Flags().Lookup(flag), flag)
+		}
+	})
+
+	t.Run("Command execution", func(t *testing.T) {
+		viper.Reset()
+		BindEnv()
+
+		var executedSync bool
+		cmd := CreateSyncContentCmdHandler(func(cfg SyncConfig, logger *slog.Logger) error {
+			executedSync = true
+			require.Equal(t, DefaultHotelbedsHost, cfg.HotelbedsHost)
+			require.Equal(t, DefaultContentStorePath, cfg.StorePath)
+			require.Equal(t, DefaultContentLanguage, cfg.Language)
+			require.Equal(t, DefaultContentPageSize, cfg.PageSize)
+			require.Equal(t, DefaultContentSyncTimeout, cfg.Timeout)
+			require.False(t, cfg.Full)
+			require.Empty(t, cfg.MappingsPath)
+			return nil
+		}, nil)
+
+		require.NoError(t, cmd.Execute())
+		require.True(t, executedSync)
+	})
+
+	t.Run("Command execution with flags", func(t *testing.T) {
+		viper.Reset()
+		BindEnv()
+
+		// The start command flags sharing the same keys must not take precedence.
+		_, err := CreateStartCmdHandler(func(cfg Config, logger *slog.Logger) {}, nil)
+		require.NoError(t, err)
+
+		var executedSync bool
+		cmd := CreateSyncContentCmdHandler(func(cfg SyncConfig, logger *slog.Logger) error {
+			executedSync = true
+			require.Equal(t, "testhost", cfg.HotelbedsHost)
+			require.Equal(t, "testkey", cfg.HotelbedsApiKey)
+			require.Equal(t, "testsecret", cfg.HotelbedsSecret)
+			require.Equal(t, "/tmp/content.db", cfg.StorePath)
+			require.Equal(t, "CAS", cfg.Language)
+			require.Equal(t, 250, cfg.PageSize)
+			require.Equal(t, 2*time.Minute, cfg.Timeout)
+			require.True(t, cfg.Full)
+			require.Equal(t, "mappings.csv", cfg.MappingsPath)
+			return nil
+		}, nil)
+
+		require.NoError(t, cmd.Flags().Set("host", "testhost"))
+		require.NoError(t, cmd.Flags().Set("apikey", "testkey"))
+		require.NoError(t, cmd.Flags().Set("secret", "testsecret"))
+		require.NoError(t, cmd.Flags().Set("store", "/tmp/content.db"))
+		require.NoError(t, cmd.Flags().Set("language", "CAS"))
+		require.NoError(t, cmd.Flags().Set("page-size", "250"))
+		require.NoError(t, cmd.Flags().Set("timeout", "2m"))
+		require.NoError(t, cmd.Flags().Set("full", "true"))
+		require.NoError(t, cmd.Flags().Set("mappings", "mappings.csv"))
+
+		require.NoError(t, cmd.Execute())
+		require.True(t, executedSync)
+	})
+
+	t.Run("Sync failure", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		cmd := CreateSyncContentCmdHandler(func(cfg SyncConfig, logger *slog.Logger) error { return errBoom }, nil)
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+
+		require.ErrorIs(t, cmd.Execute(), errBoom)
+	})
+}
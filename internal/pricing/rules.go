@@ -0,0 +1,280 @@
+package pricing
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidRules is returned for a rules file which cannot be used.
+var ErrInvalidRules = errors.New("invalid pricing rules")
+
+const (
+	// MarkupPercentage adds Value percent of the net price.
+	MarkupPercentage = "percentage"
+	// MarkupFixed adds Value to the net price, in the currency of the search.
+	MarkupFixed = "fixed"
+	// MarkupCommission grosses the net price up so that Value percent of the selling price is left as commission.
+	MarkupCommission = "commission"
+
+	// RoundNearest rounds the selling price to the nearest Increment.
+	RoundNearest = "nearest"
+	// RoundUp rounds the selling price up to the next Increment.
+	RoundUp = "up"
+	// RoundDown rounds the selling price down to the previous Increment.
+	RoundDown = "down"
+
+	defaultIncrement = 0.01
+)
+
+// Rule applies its Markup to the net price of the hotels matching its conditions.
+type Rule struct {
+	Name string `json:"name" yaml:"name"`
+	// Priority orders the rules, the matching rule with the highest priority applies. Rules of the same priority
+	// apply in file order.
+	Priority int      `json:"priority" yaml:"priority"`
+	Match    Match    `json:"match" yaml:"match"`
+	Markup   Markup   `json:"markup" yaml:"markup"`
+	Rounding Rounding `json:"rounding" yaml:"rounding"`
+}
+
+// Match are the conditions of a Rule, a zero condition matches every hotel.
+type Match struct {
+	// Destinations are Hotelbeds destination codes, e.g. PMI.
+	Destinations []string `json:"destinations,omitempty" yaml:"destinations"`
+	MinStars     float64  `json:"minStars,omitempty" yaml:"minStars"`
+	MaxStars     float64  `json:"maxStars,omitempty" yaml:"maxStars"`
+	// Channels are the sales channels of the search, e.g. web or b2b.
+	Channels []string `json:"channels,omitempty" yaml:"channels"`
+	// CheckInFrom and CheckInTo bound the check-in date, both included, in YYYY-MM-DD format.
+	CheckInFrom string `json:"checkInFrom,omitempty" yaml:"checkInFrom"`
+	CheckInTo   string `json:"checkInTo,omitempty" yaml:"checkInTo"`
+}
+
+// Markup is how a Rule turns the net price into the selling price.
+type Markup struct {
+	Type  string  `json:"type" yaml:"type"`
+	Value float64 `json:"value" yaml:"value"`
+}
+
+// Rounding is how the selling price is rounded, to the nearest cent by default.
+type Rounding struct {
+	Mode      string  `json:"mode,omitempty" yaml:"mode"`
+	Increment float64 `json:"increment,omitempty" yaml:"increment"`
+}
+
+// Target is the hotel of a search a selling price is computed for.
+type Target struct {
+	Destination string
+	Stars       float64
+	Channel     string
+	// CheckIn is in YYYY-MM-DD format.
+	CheckIn string
+}
+
+// Quote is the selling price of a net price, Rule is nil when no rule matched.
+type Quote struct {
+	Net     float64
+	Selling float64
+	Rule    *Rule
+}
+
+// Rules is a set of validated rules, ordered by priority.
+type Rules struct {
+	rules []Rule
+}
+
+// NewRules validates rules and orders them by priority.
+func NewRules(rules []Rule) (*Rules, error) {
+	names := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("%w: rule %d has no name", ErrInvalidRules, i+1)
+		}
+
+		if names[rule.Name] {
+			return nil, fmt.Errorf("%w: duplicate rule %q", ErrInvalidRules, rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("%w: rule %q: %w", ErrInvalidRules, rule.Name, err)
+		}
+	}
+
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b Rule) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
+	return &Rules{rules: sorted}, nil
+}
+
+// LoadRules reads the rules of the YAML or JSON file at path, by its extension. A missing file gives no rules,
+// selling prices are then the net prices.
+func LoadRules(path string) (*Rules, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Rules{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Rules []Rule `json:"rules" yaml:"rules"`
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(raw, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &file)
+	default:
+		return nil, fmt.Errorf("%w: %s is neither a YAML nor a JSON file", ErrInvalidRules, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRules, err)
+	}
+
+	return NewRules(file.Rules)
+}
+
+// Len returns the number of rules.
+func (r *Rules) Len() int {
+	return len(r.rules)
+}
+
+// Match returns the rule with the highest priority matching target, false if none does.
+func (r *Rules) Match(target Target) (Rule, bool) {
+	for _, rule := range r.rules {
+		if rule.Match.matches(target) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// Quote returns the selling price of net for target, net itself when no rule matches.
+func (r *Rules) Quote(target Target, net float64) Quote {
+	rule, ok := r.Match(target)
+	if !ok {
+		return Quote{Net: net, Selling: net}
+	}
+
+	return Quote{Net: net, Selling: rule.apply(net), Rule: &rule}
+}
+
+// apply returns the selling price of net.
+func (r Rule) apply(net float64) float64 {
+	selling := net
+	switch r.Markup.Type {
+	case MarkupPercentage:
+		selling = net * (1 + r.Markup.Value/100)
+	case MarkupFixed:
+		selling = net + r.Markup.Value
+	case MarkupCommission:
+		selling = net / (1 - r.Markup.Value/100)
+	}
+
+	increment := r.Rounding.Increment
+	if increment <= 0 {
+		increment = defaultIncrement
+	}
+
+	steps := selling / increment
+	switch r.Rounding.Mode {
+	case RoundUp:
+		// Float noise must not push an exact price up to the next increment.
+		steps = math.Ceil(steps - 1e-9)
+	case RoundDown:
+		steps = math.Floor(steps + 1e-9)
+	default:
+		steps = math.Round(steps)
+	}
+
+	return math.Round(steps*increment*100) / 100
+}
+
+func (r Rule) validate() error {
+	switch r.Markup.Type {
+	case MarkupPercentage, MarkupFixed:
+		if r.Markup.Value < 0 {
+			return errors.New("markup must not be negative")
+		}
+	case MarkupCommission:
+		if r.Markup.Value < 0 || r.Markup.Value >= 100 {
+			return errors.New("commission must be at least 0 and less than 100 percent")
+		}
+	default:
+		return fmt.Errorf("markup type must be %s, %s or %s", MarkupPercentage, MarkupFixed, MarkupCommission)
+	}
+
+	switch r.Rounding.Mode {
+	case "", RoundNearest, RoundUp, RoundDown:
+	default:
+		return fmt.Errorf("rounding mode must be %s, %s or %s", RoundNearest, RoundUp, RoundDown)
+	}
+
+	if r.Rounding.Increment < 0 {
+		return errors.New("rounding increment must not be negative")
+	}
+
+	if r.Match.MaxStars != 0 && r.Match.MaxStars < r.Match.MinStars {
+		return errors.New("maxStars must not be lower than minStars")
+	}
+
+	for _, date := range []string{r.Match.CheckInFrom, r.Match.CheckInTo} {
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("check-in date %q must be in YYYY-MM-DD format", date)
+		}
+	}
+
+	return nil
+}
+
+func (m Match) matches(target Target) bool {
+	if len(m.Destinations) > 0 && !slices.Contains(m.Destinations, target.Destination) {
+		return false
+	}
+
+	if m.MinStars > 0 && target.Stars < m.MinStars {
+		return false
+	}
+
+	if m.MaxStars > 0 && target.Stars > m.MaxStars {
+		return false
+	}
+
+	if len(m.Channels) > 0 && !slices.Contains(m.Channels, target.Channel) {
+		return false
+	}
+
+	// Dates in YYYY-MM-DD format compare in calendar order.
+	if m.CheckInFrom != "" && target.CheckIn < m.CheckInFrom {
+		return false
+	}
+
+	if m.CheckInTo != "" && target.CheckIn > m.CheckInTo {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,199 @@
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Quote(t *testing.T) {
+	rules, err := NewRules([]Rule{
+		{
+			Name:   "default",
+			Markup: Markup{Type: MarkupPercentage, Value: 10},
+		},
+		{
+			Name:     "mallorca summer",
+			Priority: 10,
+			Match:    Match{Destinations: []string{"PMI"}, CheckInFrom: "2024-06-01", CheckInTo: "2024-08-31"},
+			Markup:   Markup{Type: MarkupFixed, Value: 15},
+			Rounding: Rounding{Mode: RoundUp, Increment: 1},
+		},
+		{
+			Name:     "luxury b2b",
+			Priority: 20,
+			Match:    Match{MinStars: 4.5, Channels: []string{"b2b"}},
+			Markup:   Markup{Type: MarkupCommission, Value: 20},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, rules.Len())
+
+	tests := []struct {
+		name        string
+		target      Target
+		net         float64
+		wantSelling float64
+		wantRule    string
+	}{
+		{
+			name:        "lowest priority",
+			target:      Target{Destination: "BCN", Stars: 3, Channel: "web", CheckIn: "2024-07-15"},
+			net:         100.05,
+			wantSelling: 110.06,
+			wantRule:    "default",
+		},
+		{
+			name:        "destination and check-in window, rounded up",
+			target:      Target{Destination: "PMI", Stars: 3, Channel: "web", CheckIn: "2024-08-31"},
+			net:         100.2,
+			wantSelling: 116,
+			wantRule:    "mallorca summer",
+		},
+		{
+			name:        "outside of check-in window",
+			target:      Target{Destination: "PMI", Stars: 3, Channel: "web", CheckIn: "2024-09-01"},
+			net:         100,
+			wantSelling: 110,
+			wantRule:    "default",
+		},
+		{
+			name:        "highest priority wins",
+			target:      Target{Destination: "PMI", Stars: 5, Channel: "b2b", CheckIn: "2024-07-15"},
+			net:         100,
+			wantSelling: 125,
+			wantRule:    "luxury b2b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := rules.Quote(tt.target, tt.net)
+			require.Equal(t, tt.net, quote.Net)
+			require.Equal(t, tt.wantSelling, quote.Selling)
+			require.NotNil(t, quote.Rule)
+			require.Equal(t, tt.wantRule, quote.Rule.Name)
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		rules, err := NewRules([]Rule{
+			{Name: "web", Match: Match{Channels: []string{"web"}}, Markup: Markup{Type: MarkupFixed, Value: 5}},
+		})
+		require.NoError(t, err)
+
+		quote := rules.Quote(Target{Channel: "b2b"}, 100)
+		require.Equal(t, Quote{Net: 100, Selling: 100}, quote)
+	})
+}
+
+func TestRule_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   Markup
+		rounding Rounding
+		net      float64
+		want     float64
+	}{
+		{"percentage", Markup{Type: MarkupPercentage, Value: 12.5}, Rounding{}, 80, 90},
+		{"fixed", Markup{Type: MarkupFixed, Value: 9.99}, Rounding{}, 80, 89.99},
+		{"commission", Markup{Type: MarkupCommission, Value: 10}, Rounding{}, 90, 100},
+		{"nearest increment", Markup{Type: MarkupFixed}, Rounding{Mode: RoundNearest, Increment: 0.5}, 80.3, 80.5},
+		{"round down", Markup{Type: MarkupFixed}, Rounding{Mode: RoundDown, Increment: 5}, 84.99, 80},
+		{"round up exact", Markup{Type: MarkupPercentage, Value: 10}, Rounding{Mode: RoundUp, Increment: 1}, 100, 110},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := Rule{Name: tt.name, Markup: tt.markup, Rounding: tt.rounding}
+			require.Equal(t, tt.want, rule.apply(tt.net))
+		})
+	}
+}
+
+func TestNewRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"no name", Rule{Markup: Markup{Type: MarkupFixed}}},
+		{"unknown markup", Rule{Name: "a", Markup: Markup{Type: "discount"}}},
+		{"negative markup", Rule{Name: "a", Markup: Markup{Type: MarkupPercentage, Value: -5}}},
+		{"full commission", Rule{Name: "a", Markup: Markup{Type: MarkupCommission, Value: 100}}},
+		{"unknown rounding", Rule{Name: "a", Markup: Markup{Type: MarkupFixed}, Rounding: Rounding{Mode: "even"}}},
+		{"stars range", Rule{Name: "a", Markup: Markup{Type: MarkupFixed}, Match: Match{MinStars: 4, MaxStars: 3}}},
+		{"invalid date", Rule{Name: "a", Markup: Markup{Type: MarkupFixed}, Match: Match{CheckInFrom: "01/06/2024"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRules([]Rule{tt.rule})
+			require.ErrorIs(t, err, ErrInvalidRules)
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		rule := Rule{Name: "a", Markup: Markup{Type: MarkupFixed}}
+		_, err := NewRules([]Rule{rule, rule})
+		require.ErrorIs(t, err, ErrInvalidRules)
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		rules, err := LoadRules(filepath.Join(t.TempDir(), "pricing.yaml"))
+		require.NoError(t, err)
+		require.Zero(t, rules.Len())
+	})
+
+	files := map[string]string{
+		"pricing.yaml": `
+rules:
+  - name: mallorca
+    priority: 10
+    match:
+      destinations: [PMI]
+    markup:
+      type: percentage
+      value: 10
+`,
+		"pricing.json": `{"rules": [{"name": "mallorca", "priority": 10, "match": {"destinations": ["PMI"]},
+			"markup": {"type": "percentage", "value": 10}}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			rules, err := LoadRules(path)
+			require.NoError(t, err)
+			rule, ok := rules.Match(Target{Destination: "PMI"})
+			require.True(t, ok)
+			require.Equal(t, Rule{
+				Name:     "mallorca",
+				Priority: 10,
+				Match:    Match{Destinations: []string{"PMI"}},
+				Markup:   Markup{Type: MarkupPercentage, Value: 10},
+			}, rule)
+		})
+	}
+
+	t.Run("unknown extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pricing.toml")
+		require.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+
+		_, err := LoadRules(path)
+		require.ErrorIs(t, err, ErrInvalidRules)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pricing.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("rules: {"), 0o600))
+
+		_, err := LoadRules(path)
+		require.ErrorIs(t, err, ErrInvalidRules)
+	})
+}
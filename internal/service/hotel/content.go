@@ -0,0 +1,115 @@
+package hotel
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/supplier"
+	"strconv"
+	"strings"
+)
+
+const (
+	// photosBaseURL is the host of the images of the Content API, image paths are relative to it.
+	photosBaseURL = "https://photos.hotelbeds.com/giata/"
+
+	// ErrCodeHotelNotFound is the code of the error returned for a hotel unknown to the Content API.
+	ErrCodeHotelNotFound = "hotel_not_found"
+)
+
+// HotelContent fetches the static data of a hotel from the Hotelbeds Content API. The lite API hotel id is
+// translated to its Hotelbeds code, like in searches.
+func (t *HotelS) HotelContent(ctx context.Context, req dto.HotelContentRequest) (dto.HotelContentResponse, error) {
+	if err := requireHotelbeds(ctx); err != nil {
+		return dto.HotelContentResponse{}, err
+	}
+
+	contentReq := req
+	if t.mapper != nil {
+		code, ok := t.mapper.Code(supplier.HotelbedsName, req.HotelID)
+		if !ok {
+			return dto.HotelContentResponse{}, liteapierrors.NewNotFoundErr(ErrCodeHotelNotFound,
+				fmt.Sprintf("hotel %d not found", req.HotelID))
+		}
+
+		contentReq.HotelID = code
+	}
+
+	res, err := t.content.Hotels(ctx, contentReq.Transform())
+	if err != nil {
+		return dto.HotelContentResponse{}, err
+	}
+
+	for _, hotel := range res.Hotels {
+		if hotel.Code == contentReq.HotelID {
+			data := transformContent(hotel)
+			data.HotelID = strconv.Itoa(req.HotelID)
+			return dto.HotelContentResponse{Data: data}, nil
+		}
+	}
+
+	return dto.HotelContentResponse{}, liteapierrors.NewNotFoundErr(ErrCodeHotelNotFound,
+		fmt.Sprintf("hotel %d not found", req.HotelID))
+}
+
+// transformContent normalizes the Content API hotel into the lite API contract.
+func transformContent(hotel client.ContentHotel) dto.HotelContent {
+	street := hotel.Address.Content
+	if hotel.Address.Street != "" {
+		street = strings.TrimSpace(hotel.Address.Street + " " + hotel.Address.Number)
+	}
+
+	data := dto.HotelContent{
+		HotelID:           strconv.Itoa(hotel.Code),
+		Name:              hotel.Name.Content,
+		Description:       hotel.Description.Content,
+		Category:          hotel.CategoryCode,
+		StarRating:        client.StarRating(hotel.CategoryCode),
+		Chain:             hotel.ChainCode,
+		AccommodationType: hotel.AccommodationTypeCode,
+		Address: dto.Address{
+			Street:          street,
+			PostalCode:      hotel.PostalCode,
+			City:            hotel.City.Content,
+			CountryCode:     hotel.CountryCode,
+			DestinationCode: hotel.DestinationCode,
+			ZoneCode:        hotel.ZoneCode,
+		},
+		Coordinates: dto.Coordinates{
+			Latitude:  hotel.Coordinates.Latitude,
+			Longitude: hotel.Coordinates.Longitude,
+		},
+		Email:      hotel.Email,
+		Web:        hotel.Web,
+		LastUpdate: hotel.LastUpdate,
+	}
+
+	for _, phone := range hotel.Phones {
+		data.Phones = append(data.Phones, dto.Phone{
+			Number: phone.PhoneNumber,
+			Type:   phone.PhoneType,
+		})
+	}
+
+	for _, facility := range hotel.Facilities {
+		data.Facilities = append(data.Facilities, dto.Facility{
+			Code:        facility.FacilityCode,
+			GroupCode:   facility.FacilityGroupCode,
+			Description: facility.Description.Content,
+			Fee:         facility.IndFee,
+		})
+	}
+
+	for _, image := range hotel.Images {
+		data.Images = append(data.Images, dto.Image{
+			URL:      photosBaseURL + image.Path,
+			Type:     image.ImageTypeCode,
+			Order:    image.Order,
+			RoomCode: image.RoomCode,
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,142 @@
+package hotel
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/mapping"
+	"lite-api/internal/supplier"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+//go:embed testdata/hotelbeds_content_response.json
+var hotelbedsContentResponse []byte
+
+func TestHotel_HotelContent(t *testing.T) {
+	var contentResp client.ContentHotelsResponse
+	require.NoError(t, json.Unmarshal(hotelbedsContentResponse, &contentResp))
+	req := dto.HotelContentRequest{HotelID: 1067, Language: "ENG"}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+		content.EXPECT().Hotels(gomock.Any(), req.Transform()).Return(contentResp, nil)
+
+		hotelService := NewHotelService(nil, nil, nil, content, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.HotelContent(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, dto.HotelContent{
+			HotelID:           "1067",
+			Name:              "Hotel Bellevue",
+			Description:       "Family run hotel a short walk from the beach.",
+			Category:          "3EST",
+			StarRating:        3,
+			Chain:             "BELLE",
+			AccommodationType: "HOTEL",
+			Address: dto.Address{
+				Street:          "Calle Mayor 12",
+				PostalCode:      "07001",
+				City:            "PALMA DE MALLORCA",
+				CountryCode:     "ES",
+				DestinationCode: "PMI",
+				ZoneCode:        20,
+			},
+			Coordinates: dto.Coordinates{Latitude: 39.563378, Longitude: 2.630194},
+			Email:       "info@bellevue.example",
+			Web:         "www.bellevue.example",
+			Phones:      dto.Phones{{Number: "0034971000000", Type: "PHONEBOOKING"}},
+			Facilities: dto.Facilities{
+				{Code: 10, GroupCode: 70, Description: "Swimming pool"},
+				{Code: 261, GroupCode: 60, Description: "Wi-fi", Fee: true},
+			},
+			Images: dto.Images{
+				{URL: photosBaseURL + "00/001067/001067a_hb_a_001.jpg", Type: "GEN", Order: 1},
+				{URL: photosBaseURL + "00/001067/001067a_hb_ro_002.jpg", Type: "HAB", Order: 2, RoomCode: "DBL.ST"},
+			},
+			LastUpdate: "2024-06-30",
+		}, res.Data)
+	})
+
+	t.Run("address without street", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+		other := dto.HotelContentRequest{HotelID: 1068}
+		content.EXPECT().Hotels(gomock.Any(), other.Transform()).Return(contentResp, nil)
+
+		hotelService := NewHotelService(nil, nil, nil, content, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.HotelContent(context.Background(), other)
+		require.NoError(t, err)
+		require.Equal(t, "Avenida del Mar, 3", res.Data.Address.Street)
+		require.Equal(t, float64(2), res.Data.StarRating)
+		require.Empty(t, res.Data.Images)
+	})
+
+	t.Run("hotel id translated to the hotelbeds code", func(t *testing.T) {
+		table, err := mapping.NewTable([]mapping.Row{{HotelID: 129410, Supplier: supplier.HotelbedsName, Code: 1067}})
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+		content.EXPECT().Hotels(gomock.Any(), req.Transform()).Return(contentResp, nil)
+
+		hotelService := NewHotelService(nil, nil, table, content, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.HotelContent(context.Background(), dto.HotelContentRequest{
+			HotelID:  129410,
+			Language: "ENG",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "129410", res.Data.HotelID)
+		require.Equal(t, "Hotel Bellevue", res.Data.Name)
+
+		_, err = hotelService.HotelContent(context.Background(), req)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeHotelNotFound, apiErr.Code())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+		content.EXPECT().Hotels(gomock.Any(), gomock.Any()).Return(client.ContentHotelsResponse{}, nil)
+
+		hotelService := NewHotelService(nil, nil, nil, content, nil, nil, nil, Config{}, nil)
+		_, err := hotelService.HotelContent(context.Background(), req)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeHotelNotFound, apiErr.Code())
+		require.Equal(t, liteapierrors.KindNotFound, apiErr.Kind())
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+		errBoom := errors.New("boom")
+		content.EXPECT().Hotels(gomock.Any(), gomock.Any()).Return(client.ContentHotelsResponse{}, errBoom)
+
+		hotelService := NewHotelService(nil, nil, nil, content, nil, nil, nil, Config{}, nil)
+		_, err := hotelService.HotelContent(context.Background(), req)
+		require.ErrorIs(t, err, errBoom)
+	})
+
+	t.Run("other supplier requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		content := hotelbedsmock.NewMockHotelContent(ctrl)
+
+		hotelService := NewHotelService(nil, nil, nil, content, nil, nil, nil, Config{}, nil)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Supplier: "other"})
+		_, err := hotelService.HotelContent(ctx, req)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeUnknownSupplier, apiErr.Code())
+		require.Equal(t, http.StatusBadRequest, apiErr.HTTPStatus())
+	})
+}
@@ -0,0 +1,45 @@
+package hotel
+
+import (
+	"lite-api/internal/dto"
+	"math"
+)
+
+// ExchangeRates provides the rates prices are converted to the requested currency with.
+type ExchangeRates interface {
+	// Rate returns the units of to one unit of from is worth, false when no rate is known.
+	Rate(from, to string) (float64, bool)
+}
+
+// exchangeRate returns the rate from the supplier currency to the requested one. Without ExchangeRates only
+// hotels priced in the requested currency can be returned.
+func (t *HotelS) exchangeRate(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	if t.rates == nil {
+		return 0, false
+	}
+
+	return t.rates.Rate(from, to)
+}
+
+// convert returns amount converted with rate, rounded to cents.
+func convert(amount, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
+
+// convertRooms converts the rate prices and cancellation amounts of rooms in place. Taxes keep the currency they
+// are reported in.
+func convertRooms(rooms dto.RoomInfos, rate float64) {
+	for _, room := range rooms {
+		for i := range room.Rates {
+			room.Rates[i].Price = convert(room.Rates[i].Price, rate)
+			for j := range room.Rates[i].CancellationPolicies {
+				policy := &room.Rates[i].CancellationPolicies[j]
+				policy.Amount = convert(policy.Amount, rate)
+			}
+		}
+	}
+}
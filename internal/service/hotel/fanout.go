@@ -0,0 +1,112 @@
+package hotel
+
+import (
+	"context"
+	"fmt"
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/supplier"
+	"slices"
+	"sync"
+)
+
+// supplierAvailability is the availability found by the supplier called name, with hotel ids translated back
+// to lite API ones.
+type supplierAvailability struct {
+	supplier.Availability
+	name string
+	// dropped are the hotels whose supplier code maps to no hotel id.
+	dropped dto.DroppedHotels
+}
+
+// supplierSearch fans out a search to the suppliers supporting its scope.
+type supplierSearch struct {
+	found    []supplierAvailability
+	failures dto.SupplierFailures
+	unmapped dto.UnmappedHotels
+}
+
+// searchSuppliers searches the suppliers supporting the scope of searchReq concurrently and returns their
+// availability in supplier order. Suppliers are only sent the hotel ids mapped to their codes, and not searched
+// when none is. Failed suppliers are reported as failures, err is only returned when every supplier searched
+// failed. Only the supplier named by the supplier config of ctx is searched when it names one.
+func (t *HotelS) searchSuppliers(ctx context.Context, searchReq client.SearchRequest) (supplierSearch, error) {
+	requested := client.SupplierConfigFrom(ctx).Supplier
+	if requested != "" && !slices.ContainsFunc(t.suppliers, func(s supplier.Supplier) bool {
+		return s.Name() == requested
+	}) {
+		return supplierSearch{}, liteapierrors.NewInvalidRequestErr(ErrCodeUnknownSupplier,
+			fmt.Sprintf("supplier %s is not enabled", requested))
+	}
+
+	var search supplierSearch
+	scope := supplier.Scope(searchReq)
+	var capable []supplier.Supplier
+	var requests []client.SearchRequest
+	for _, s := range t.suppliers {
+		if requested != "" && s.Name() != requested || !s.Capabilities().Has(scope) {
+			continue
+		}
+
+		req, unmapped := t.mapRequest(s.Name(), searchReq)
+		if len(unmapped) > 0 {
+			search.unmapped = append(search.unmapped, dto.UnmappedHotel{Supplier: s.Name(), HotelIds: unmapped})
+		}
+
+		if req.Hotels == nil || len(req.Hotels.Hotel) > 0 {
+			capable, requests = append(capable, s), append(requests, req)
+		}
+	}
+
+	if len(capable) == 0 {
+		if len(search.unmapped) > 0 {
+			return search, nil
+		}
+
+		return supplierSearch{}, liteapierrors.NewInvalidRequestErr(ErrCodeNoSupplier,
+			fmt.Sprintf("no enabled supplier supports %s searches", scope))
+	}
+
+	availabilities := make([]supplier.Availability, len(capable))
+	errs := make([]error, len(capable))
+	var wg sync.WaitGroup
+	for i, s := range capable {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			availabilities[i], errs[i] = s.Search(ctx, requests[i])
+		}()
+	}
+
+	wg.Wait()
+
+	var firstErr error
+	for i, s := range capable {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+
+			search.failures = append(search.failures, dto.SupplierFailure{
+				Supplier: s.Name(),
+				Error:    dto.NewErrorDetail(errs[i]),
+			})
+
+			continue
+		}
+
+		availability, dropped := t.mapAvailability(s.Name(), availabilities[i])
+		search.found = append(search.found, supplierAvailability{
+			Availability: availability,
+			name:         s.Name(),
+			dropped:      dropped,
+		})
+	}
+
+	if len(search.found) == 0 {
+		return supplierSearch{}, firstErr
+	}
+
+	return search, nil
+}
@@ -0,0 +1,132 @@
+package hotel
+
+import (
+	"context"
+	"encoding/json"
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/supplier"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSupplier is a supplier.Supplier returning a fixed availability or error.
+type fakeSupplier struct {
+	name         string
+	capabilities supplier.Capabilities
+	availability supplier.Availability
+	err          error
+}
+
+func (f fakeSupplier) Name() string { return f.name }
+
+func (f fakeSupplier) Capabilities() supplier.Capabilities { return f.capabilities }
+
+func (f fakeSupplier) Search(context.Context, client.SearchRequest) (supplier.Availability, error) {
+	return f.availability, f.err
+}
+
+func TestHotel_SearchSuppliers(t *testing.T) {
+	searchReq := dto.SearchRequest{
+		Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+		HotelIds:         "264,77",
+		CheckIn:          "2024-07-15",
+		CheckOut:         "2024-07-16",
+		Currency:         "EUR",
+		GuestNationality: "US",
+	}
+
+	all := supplier.Capabilities{supplier.CapabilityHotelIds, supplier.CapabilityDestination}
+	first := fakeSupplier{
+		name:         "first",
+		capabilities: all,
+		availability: supplier.Availability{
+			Hotels:   supplier.Hotels{{ID: "264", Currency: "EUR", MinRate: 384.25}},
+			Response: json.RawMessage(`{"first":true}`),
+		},
+	}
+	second := fakeSupplier{
+		name:         "second",
+		capabilities: all,
+		availability: supplier.Availability{
+			Hotels:   supplier.Hotels{{ID: "A77", Currency: "EUR", MinRate: 336.24}},
+			Failures: supplier.Failures{{Batch: 1, HotelIds: []int{168}, Err: assert.AnError}},
+			Response: json.RawMessage(`{"second":true}`),
+		},
+	}
+	failing := fakeSupplier{name: "failing", capabilities: all, err: assert.AnError}
+	geoOnly := fakeSupplier{name: "geo", capabilities: supplier.Capabilities{supplier.CapabilityGeolocation}}
+
+	t.Run("merge suppliers", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{first, second, geoOnly}, nil, nil, nil, nil, nil, Config{},
+			nil)
+		res, err := hotelService.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+
+		require.Equal(t, dto.HotelInfos{
+			{HotelID: "264", Supplier: "first", Currency: "EUR", Price: 384.25, NetPrice: 384.25, SellingPrice: 384.25},
+			{HotelID: "A77", Supplier: "second", Currency: "EUR", Price: 336.24, NetPrice: 336.24, SellingPrice: 336.24},
+		}, res.Data)
+		request, err := json.Marshal(searchReq)
+		require.NoError(t, err)
+		require.Equal(t, dto.Suppliers{
+			{Name: "first", Request: request, Response: json.RawMessage(`{"first":true}`)},
+			{Name: "second", Request: request, Response: json.RawMessage(`{"second":true}`)},
+		}, res.Suppliers)
+		require.Equal(t, dto.BatchFailures{{Supplier: "second", Batch: 1, HotelIds: []int{168},
+			Error: dto.NewErrorDetail(assert.AnError)}}, res.Failures)
+		require.Empty(t, res.SupplierFailures)
+	})
+
+	t.Run("partial supplier failure", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{failing, first}, nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "first", res.Data[0].Supplier)
+		require.Equal(t, dto.SupplierFailures{{Supplier: "failing", Error: dto.NewErrorDetail(assert.AnError)}},
+			res.SupplierFailures)
+	})
+
+	t.Run("every supplier failed", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{failing}, nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.Search(context.Background(), searchReq)
+		require.ErrorIs(t, err, assert.AnError)
+		require.Zero(t, res)
+	})
+
+	t.Run("no capable supplier", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{geoOnly}, nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.Search(context.Background(), searchReq)
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeNoSupplier, apiErr.Code())
+		require.Zero(t, res)
+	})
+	t.Run("supplier requested by the supplier config", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{first, second}, nil, nil, nil, nil, nil, Config{}, nil)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Supplier: "second"})
+		res, err := hotelService.Search(ctx, searchReq)
+		require.NoError(t, err)
+
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "second", res.Data[0].Supplier)
+		require.Len(t, res.Suppliers, 1)
+	})
+
+	t.Run("supplier requested is not enabled", func(t *testing.T) {
+		hotelService := NewHotelService(nil, []supplier.Supplier{first}, nil, nil, nil, nil, nil, Config{}, nil)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Supplier: "second"})
+		res, err := hotelService.Search(ctx, searchReq)
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeUnknownSupplier, apiErr.Code())
+		require.Zero(t, res)
+	})
+}
@@ -0,0 +1,42 @@
+package hotel
+
+import (
+	"lite-api/internal/dto"
+	"lite-api/internal/supplier"
+	"math"
+)
+
+// refundableRates returns hotel without its non-refundable rates, and with its minimum rate recomputed from the
+// remaining ones, false when it has no refundable rate left.
+func refundableRates(hotel supplier.Hotel) (supplier.Hotel, bool) {
+	var rooms supplier.Rooms
+	minRate := math.Inf(1)
+	for _, room := range hotel.Rooms {
+		var rates supplier.Rates
+		for _, rate := range room.Rates {
+			if !rate.Refundable {
+				continue
+			}
+
+			rates = append(rates, rate)
+			minRate = min(minRate, rate.Net)
+		}
+
+		if len(rates) > 0 {
+			room.Rates = rates
+			rooms = append(rooms, room)
+		}
+	}
+
+	if len(rooms) == 0 {
+		return supplier.Hotel{}, false
+	}
+
+	hotel.Rooms, hotel.MinRate = rooms, minRate
+	return hotel, true
+}
+
+// inPriceRange reports whether the selling price, in the requested currency, is within the price bounds of req.
+func inPriceRange(req dto.SearchRequest, price float64) bool {
+	return price >= req.MinPrice && (req.MaxPrice == 0 || price <= req.MaxPrice)
+}
@@ -0,0 +1,70 @@
+package hotel
+
+import (
+	"lite-api/internal/supplier"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundableRates(t *testing.T) {
+	tests := []struct {
+		name   string
+		hotel  supplier.Hotel
+		want   supplier.Hotel
+		wantOK bool
+	}{
+		{
+			name: "drops non-refundable rates and rooms",
+			hotel: supplier.Hotel{
+				ID:      "1",
+				MinRate: 90,
+				Rooms: supplier.Rooms{
+					{Code: "DBL", Rates: supplier.Rates{
+						{RateKey: "a", Net: 90},
+						{RateKey: "b", Refundable: true, Net: 120.5},
+					}},
+					{Code: "SGL", Rates: supplier.Rates{
+						{RateKey: "c", Net: 70},
+					}},
+					{Code: "TPL", Rates: supplier.Rates{
+						{RateKey: "d", Refundable: true, Net: 110.25},
+					}},
+				},
+			},
+			want: supplier.Hotel{
+				ID:      "1",
+				MinRate: 110.25,
+				Rooms: supplier.Rooms{
+					{Code: "DBL", Rates: supplier.Rates{{RateKey: "b", Refundable: true, Net: 120.5}}},
+					{Code: "TPL", Rates: supplier.Rates{{RateKey: "d", Refundable: true, Net: 110.25}}},
+				},
+			},
+			wantOK: true,
+		},
+		{
+			name: "no refundable rate",
+			hotel: supplier.Hotel{
+				ID:      "1",
+				MinRate: 90,
+				Rooms: supplier.Rooms{
+					{Code: "DBL", Rates: supplier.Rates{{Net: 90}}},
+				},
+			},
+			wantOK: false,
+		},
+		{
+			name:   "no rates",
+			hotel:  supplier.Hotel{ID: "1", MinRate: 90},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := refundableRates(tt.hotel)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
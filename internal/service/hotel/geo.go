@@ -0,0 +1,36 @@
+package hotel
+
+import (
+	"lite-api/internal/client"
+	"lite-api/internal/supplier"
+	"math"
+)
+
+const (
+	earthRadiusKm    = 6371.0088
+	earthRadiusMiles = 3958.7613
+)
+
+// distanceFrom returns the great-circle distance of coordinates from the center of geolocation in its unit, rounded
+// to two decimals of the unit.
+func distanceFrom(geolocation client.Geolocation, coordinates supplier.Coordinates) float64 {
+	radius := earthRadiusKm
+	if geolocation.Unit == client.UnitMiles {
+		radius = earthRadiusMiles
+	}
+
+	distance := haversine(geolocation.Latitude, geolocation.Longitude, coordinates.Latitude, coordinates.Longitude) * radius
+	return math.Round(distance*100) / 100
+}
+
+// haversine returns the central angle in radians between two points given in degrees.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
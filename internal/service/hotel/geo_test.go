@@ -0,0 +1,46 @@
+package hotel
+
+import (
+	"lite-api/internal/client"
+	"lite-api/internal/supplier"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistanceFrom(t *testing.T) {
+	london := client.Geolocation{Latitude: 51.5074, Longitude: -0.1278, Radius: 500, Unit: client.UnitKilometers}
+	paris := supplier.Coordinates{Latitude: 48.8566, Longitude: 2.3522}
+
+	tests := []struct {
+		name        string
+		geolocation client.Geolocation
+		coordinates supplier.Coordinates
+		want        float64
+	}{
+		{
+			name:        "kilometers",
+			geolocation: london,
+			coordinates: paris,
+			want:        343.56,
+		},
+		{
+			name:        "miles",
+			geolocation: client.Geolocation{Latitude: london.Latitude, Longitude: london.Longitude, Unit: client.UnitMiles},
+			coordinates: paris,
+			want:        213.48,
+		},
+		{
+			name:        "same point",
+			geolocation: client.Geolocation{Latitude: 48.8566, Longitude: 2.3522, Unit: client.UnitKilometers},
+			coordinates: paris,
+			want:        0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.want, distanceFrom(tt.geolocation, tt.coordinates), 0.01)
+		})
+	}
+}
@@ -3,70 +3,456 @@ package hotel
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"lite-api/internal/client"
+	"lite-api/internal/content"
 	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/model"
+	"lite-api/internal/pricing"
+	"lite-api/internal/supplier"
 	"log/slog"
 	"strconv"
+	"time"
+
+	"go.nhat.io/clock"
+)
+
+const (
+	// ErrCodeNoSupplier is the code of the error returned for a search no enabled supplier supports.
+	ErrCodeNoSupplier = "no_supplier"
+	// ErrCodeUnknownSupplier is the code of the error returned for a supplier config naming a supplier that is not
+	// enabled, or that does not serve the request.
+	ErrCodeUnknownSupplier = "unknown_supplier"
 )
 
-// HotelS does the transformation from lite API request and search on Hotelbeds.
+// Config tunes HotelS, zero values fall back to defaults.
+type Config struct {
+	// CursorTTL is how long the results of a paginated search are kept for its next pages.
+	CursorTTL time.Duration
+	// MaxCursorBytes bounds the memory held by the results of paginated searches, the oldest are dropped first.
+	MaxCursorBytes int
+}
+
+// Directory looks up the static data of hotels kept locally.
+type Directory interface {
+	Lookup(code int) (content.HotelSummary, bool)
+}
+
+// HotelS searches the enabled suppliers and translates their results to the lite API contract. Rates are checked
+// and booked on Hotelbeds.
 type HotelS struct {
-	cli    client.HotelBeds
-	logger *slog.Logger
+	cli       client.HotelBeds
+	suppliers []supplier.Supplier
+	mapper    Mapper
+	content   client.HotelContent
+	directory Directory
+	rates     ExchangeRates
+	pricer    Pricer
+	pages     *pageStore
+	cfg       Config
+	logger    *slog.Logger
 }
 
-func NewHotelService(cli client.HotelBeds, logger *slog.Logger) *HotelS {
+// NewHotelService returns HotelS searching suppliers, hotel ids are translated to the codes of every supplier by
+// mapper, or sent as they are when it is nil. Availability results are enriched with the static data of
+// directory when it is not nil. Prices in another currency than the requested one are converted with rates, hotels
+// are dropped when rates is nil. Net prices are marked up by pricer, selling prices are the net prices when it is nil.
+func NewHotelService(cli client.HotelBeds, suppliers []supplier.Supplier, mapper Mapper,
+	contentCli client.HotelContent, directory Directory, rates ExchangeRates, pricer Pricer, cfg Config,
+	logger *slog.Logger) *HotelS {
+	if cfg.CursorTTL <= 0 {
+		cfg.CursorTTL = defaultCursorTTL
+	}
+
+	if cfg.MaxCursorBytes <= 0 {
+		cfg.MaxCursorBytes = defaultMaxCursorBytes
+	}
+
 	return &HotelS{
-		cli:    cli,
-		logger: logger,
+		cli:       cli,
+		suppliers: suppliers,
+		mapper:    mapper,
+		content:   contentCli,
+		directory: directory,
+		rates:     rates,
+		pricer:    pricer,
+		pages:     newPageStore(cfg.CursorTTL, cfg.MaxCursorBytes, clock.New()),
+		cfg:       cfg,
+		logger:    logger,
 	}
 }
 
-// Search transforms the request and searches Hotelbeds using client dependency.
+// Search transforms the request and searches the suppliers supporting its scope, merging their results. The next
+// pages of a paginated search are served from its first response rather than searched again.
 func (t *HotelS) Search(ctx context.Context, req dto.SearchRequest) (dto.SearchResponse, error) {
+	if req.Cursor != "" {
+		return t.searchPage(ctx, req)
+	}
+
 	searchReq, err := req.Transform()
 	if err != nil {
 		return dto.SearchResponse{}, err
 	}
 
-	res, err := t.cli.Search(ctx, searchReq)
+	search, err := t.searchSuppliers(ctx, searchReq)
 	if err != nil {
 		return dto.SearchResponse{}, err
 	}
 
-	filteredHoteInfos := make(dto.HotelInfos, 0)
-	for _, hotel := range res.Hotels.Hotels {
-		if req.Currency.String() != hotel.Currency {
-			continue
+	// Like for the other operations, the request payload is the lite API request every supplier was searched with.
+	request, err := json.Marshal(req)
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	var (
+		results   []searchResult
+		failures  dto.BatchFailures
+		dropped   dto.DroppedHotels
+		suppliers = make(dto.Suppliers, 0, len(search.found))
+	)
+
+	for _, availability := range search.found {
+		dropped = append(dropped, availability.dropped...)
+		suppliers = append(suppliers, dto.Supplier{
+			Name:     availability.name,
+			Request:  request,
+			Response: availability.Response,
+		})
+
+		for _, failure := range availability.Failures {
+			failures = append(failures, batchFailure(availability.name, failure))
 		}
 
-		minRate, err := strconv.ParseFloat(hotel.MinRate, 64)
-		if err != nil {
-			continue
+		for _, hotel := range availability.Hotels {
+			rate, ok := t.exchangeRate(hotel.Currency, req.Currency.String())
+			if !ok {
+				dropped = append(dropped, dto.DroppedHotel{
+					HotelID:  hotel.ID,
+					Supplier: availability.name,
+					Currency: hotel.Currency,
+					Reason:   dto.DropNoExchangeRate,
+				})
+				continue
+			}
+
+			// Refundability is not a supplier filter, the other filters were sent along with the search.
+			if req.RefundableOnly {
+				if hotel, ok = refundableRates(hotel); !ok {
+					continue
+				}
+			}
+
+			result := t.searchResult(req, searchReq, availability.name, hotel, rate)
+			if !inPriceRange(req, result.info.SellingPrice) {
+				continue
+			}
+
+			results = append(results, result)
 		}
+	}
 
-		filteredHoteInfos = append(filteredHoteInfos, dto.HotelInfo{
-			Price:    minRate,
-			Currency: hotel.Currency,
-			HotelID:  strconv.Itoa(hotel.Code),
-		})
+	var first dto.Supplier
+	if len(suppliers) > 0 {
+		first = suppliers[0]
+	}
+
+	sortResults(results, req)
+	filteredHoteInfos := make(dto.HotelInfos, len(results))
+	for i, result := range results {
+		filteredHoteInfos[i] = result.info
+	}
+
+	return t.paginate(ctx, req, dto.SearchResponse{
+		Data:             filteredHoteInfos,
+		Failures:         failures,
+		SupplierFailures: search.failures,
+		Supplier:         first,
+		Suppliers:        suppliers,
+		Total:            len(filteredHoteInfos),
+		Sort:             req.Sort,
+		Dropped:          dropped,
+		Unmapped:         search.unmapped,
+	})
+}
+
+// searchResult converts an available hotel of supplierName to the lite API contract, rate converting its prices
+// to the requested currency.
+func (t *HotelS) searchResult(req dto.SearchRequest, searchReq client.SearchRequest, supplierName string,
+	hotel supplier.Hotel, rate float64) searchResult {
+	hotelInfo := dto.HotelInfo{
+		HotelID:  hotel.ID,
+		Supplier: supplierName,
+		NetPrice: hotel.MinRate,
+		Currency: hotel.Currency,
+	}
+
+	converted := hotel.Currency != req.Currency.String()
+	if converted {
+		hotelInfo.NetPrice, hotelInfo.Currency = convert(hotel.MinRate, rate), req.Currency.String()
+		hotelInfo.Conversion = &dto.Conversion{
+			SourceCurrency: hotel.Currency,
+			SourcePrice:    hotel.MinRate,
+			Rate:           rate,
+		}
+	}
+
+	// Sorting falls back to the availability name and category of hotels missing from the directory.
+	result := searchResult{name: hotel.Name, stars: hotel.StarRating}
+	if t.directory != nil && hotel.ContentCode != 0 {
+		if summary, ok := t.directory.Lookup(hotel.ContentCode); ok {
+			hotelInfo.Name, hotelInfo.StarRating = summary.Name, summary.StarRating
+			result.name, result.stars = summary.Name, summary.StarRating
+		}
+	}
+
+	// Markups apply to converted prices, so that fixed markups are in the requested currency.
+	target := pricing.Target{
+		Destination: hotel.Destination,
+		Stars:       result.stars,
+		Channel:     req.Channel,
+		CheckIn:     req.CheckIn.String(),
+	}
+	hotelInfo.SellingPrice = t.quote(target, hotelInfo.NetPrice).Selling
+	hotelInfo.Price = hotelInfo.SellingPrice
+
+	if searchReq.Geolocation != nil && hotel.Coordinates != nil {
+		distance := distanceFrom(*searchReq.Geolocation, *hotel.Coordinates)
+		hotelInfo.Distance = &distance
+	}
+
+	if req.Detail == dto.DetailRates {
+		hotelInfo.Rooms = roomInfos(hotel.Rooms)
+		if converted {
+			convertRooms(hotelInfo.Rooms, rate)
+		}
+		t.priceRooms(hotelInfo.Rooms, target)
+	}
+
+	result.info = hotelInfo
+	return result
+}
+
+// CheckRate re-prices the requested rates on Hotelbeds using client dependency.
+func (t *HotelS) CheckRate(ctx context.Context, req dto.CheckRateRequest) (dto.CheckRateResponse, error) {
+	if err := requireHotelbeds(ctx); err != nil {
+		return dto.CheckRateResponse{}, err
+	}
+
+	res, err := t.cli.CheckRate(withMarket(ctx, req.GuestNationality), req.Transform())
+	if err != nil {
+		return dto.CheckRateResponse{}, err
+	}
+
+	netPrice, err := strconv.ParseFloat(res.Hotel.TotalNet, 64)
+	if err != nil {
+		return dto.CheckRateResponse{}, fmt.Errorf("error parsing total net price: %w", err)
+	}
+
+	checkedRates := make(dto.CheckedRates, 0)
+	for _, room := range res.Hotel.Rooms {
+		for _, rate := range room.Rates {
+			rateNet, err := strconv.ParseFloat(rate.Net, 64)
+			if err != nil {
+				return dto.CheckRateResponse{}, fmt.Errorf("error parsing net price of rate %s: %w", rate.RateKey, err)
+			}
+
+			policies, err := supplier.TransformCancellationPolicies(rate.CancellationPolicies)
+			if err != nil {
+				return dto.CheckRateResponse{}, err
+			}
+
+			checkedRates = append(checkedRates, dto.CheckedRate{
+				RateKey:              rate.RateKey,
+				RoomCode:             room.Code,
+				RoomName:             room.Name,
+				BoardCode:            rate.BoardCode,
+				NetPrice:             rateNet,
+				RateComments:         rate.RateComments,
+				CancellationPolicies: cancellationPolicies(policies),
+			})
+		}
+	}
+
+	supplier, err := supplierPayload(req, res)
+	if err != nil {
+		return dto.CheckRateResponse{}, err
+	}
+
+	return dto.CheckRateResponse{
+		Data: dto.CheckRateInfo{
+			HotelID:  strconv.Itoa(res.Hotel.Code),
+			CheckIn:  res.Hotel.CheckIn,
+			CheckOut: res.Hotel.CheckOut,
+			Currency: res.Hotel.Currency,
+			NetPrice: netPrice,
+			Rates:    checkedRates,
+		},
+		Supplier: supplier,
+	}, nil
+}
+
+// Book confirms the booking on Hotelbeds using client dependency.
+func (t *HotelS) Book(ctx context.Context, req dto.BookingRequest) (dto.BookingResponse, error) {
+	if err := requireHotelbeds(ctx); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	res, err := t.cli.Book(withMarket(ctx, req.GuestNationality), req.Transform())
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	return bookingResponse(req, res)
+}
+
+// BookingDetail fetches the booking from Hotelbeds using client dependency.
+func (t *HotelS) BookingDetail(ctx context.Context, req dto.BookingDetailRequest) (dto.BookingResponse, error) {
+	if err := requireHotelbeds(ctx); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	res, err := t.cli.BookingDetail(withMarket(ctx, req.GuestNationality), req.Reference)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	return bookingResponse(req.Reference, res)
+}
+
+// CancelBooking cancels or simulates cancelling the booking on Hotelbeds using client dependency.
+func (t *HotelS) CancelBooking(ctx context.Context, req dto.CancelBookingRequest) (dto.BookingResponse, error) {
+	if err := requireHotelbeds(ctx); err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	res, err := t.cli.CancelBooking(withMarket(ctx, req.GuestNationality), req.Reference, req.Flag())
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	return bookingResponse(req, res)
+}
+
+// requireHotelbeds refuses a supplier config of ctx naming another supplier than Hotelbeds, which serves every
+// request but searches.
+func requireHotelbeds(ctx context.Context) error {
+	if requested := client.SupplierConfigFrom(ctx).Supplier; requested != "" && requested != supplier.HotelbedsName {
+		return liteapierrors.NewInvalidRequestErr(ErrCodeUnknownSupplier,
+			fmt.Sprintf("supplier %s does not serve this request, only %s does", requested, supplier.HotelbedsName))
+	}
+
+	return nil
+}
+
+// withMarket returns ctx carrying the market of the guest nationality of a request following a search, so that it
+// is signed with the Hotelbeds account the search was. ctx is returned as it is without nationality.
+func withMarket(ctx context.Context, nationality model.Country) context.Context {
+	if nationality == "" {
+		return ctx
 	}
 
+	return client.WithMarket(ctx, nationality.ISOCode())
+}
+
+// bookingResponse transforms the Hotelbeds booking into the lite API contract.
+func bookingResponse(req any, res client.BookingResponse) (dto.BookingResponse, error) {
+	booking := res.Booking
+	rooms := make(dto.BookedRooms, len(booking.Hotel.Rooms))
+	for i, room := range booking.Hotel.Rooms {
+		guests := make(dto.Guests, len(room.Paxes))
+		for j, pax := range room.Paxes {
+			guest := dto.Guest{
+				Type:      dto.GuestAdult,
+				FirstName: pax.Name,
+				LastName:  pax.Surname,
+			}
+
+			if pax.Type == client.PaxChild {
+				guest.Type = dto.GuestChild
+				guest.Age = pax.Age
+			}
+
+			guests[j] = guest
+		}
+
+		rates := make(dto.CheckedRates, len(room.Rates))
+		for j, rate := range room.Rates {
+			rateNet, err := strconv.ParseFloat(rate.Net, 64)
+			if err != nil {
+				return dto.BookingResponse{}, fmt.Errorf("error parsing net price of room %s: %w", room.Code, err)
+			}
+
+			policies, err := supplier.TransformCancellationPolicies(rate.CancellationPolicies)
+			if err != nil {
+				return dto.BookingResponse{}, err
+			}
+
+			rates[j] = dto.CheckedRate{
+				RateKey:              rate.RateKey,
+				RoomCode:             room.Code,
+				RoomName:             room.Name,
+				BoardCode:            rate.BoardCode,
+				NetPrice:             rateNet,
+				RateComments:         rate.RateComments,
+				CancellationPolicies: cancellationPolicies(policies),
+			}
+		}
+
+		rooms[i] = dto.BookedRoom{
+			Status:   room.Status,
+			RoomCode: room.Code,
+			RoomName: room.Name,
+			Guests:   guests,
+			Rates:    rates,
+		}
+	}
+
+	supplier, err := supplierPayload(req, res)
+	if err != nil {
+		return dto.BookingResponse{}, err
+	}
+
+	return dto.BookingResponse{
+		Data: dto.BookingInfo{
+			Reference:             booking.Reference,
+			CancellationReference: booking.CancellationReference,
+			ClientReference:       booking.ClientReference,
+			Status:                booking.Status,
+			CreationDate:          booking.CreationDate,
+			Holder: dto.Holder{
+				FirstName: booking.Holder.Name,
+				LastName:  booking.Holder.Surname,
+			},
+			HotelID:            strconv.Itoa(booking.Hotel.Code),
+			HotelName:          booking.Hotel.Name,
+			CheckIn:            booking.Hotel.CheckIn,
+			CheckOut:           booking.Hotel.CheckOut,
+			Currency:           booking.Currency,
+			TotalNet:           booking.TotalNet,
+			PendingAmount:      booking.PendingAmount,
+			CancellationAmount: booking.Hotel.CancellationAmount,
+			Rooms:              rooms,
+		},
+		Supplier: supplier,
+	}, nil
+}
+
+// supplierPayload captures the request and response exchanged with Hotelbeds for transparency.
+func supplierPayload(req, res any) (dto.Supplier, error) {
 	requestPayload, err := json.Marshal(req)
 	if err != nil {
-		return dto.SearchResponse{}, err
+		return dto.Supplier{}, err
 	}
 
 	responsePayload, err := json.Marshal(res)
 	if err != nil {
-		return dto.SearchResponse{}, err
+		return dto.Supplier{}, err
 	}
 
-	return dto.SearchResponse{
-		Data: filteredHoteInfos,
-		Supplier: dto.Supplier{
-			Request:  requestPayload,
-			Response: responsePayload,
-		},
+	return dto.Supplier{
+		Request:  requestPayload,
+		Response: responsePayload,
 	}, nil
 }
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"lite-api/internal/client"
 	hotelbedsmock "lite-api/internal/client/mock"
+	"lite-api/internal/content"
 	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/supplier"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,9 +20,15 @@ import (
 //go:embed testdata/hotelbeds_response.json
 var hotelbedsResponse []byte
 
+//go:embed testdata/hotelbeds_checkrate_response.json
+var hotelbedsCheckRateResponse []byte
+
+//go:embed testdata/hotelbeds_booking_response.json
+var hotelbedsBookingResponse []byte
+
 func TestHotel_Search(t *testing.T) {
 	t.Run("transformation failure", func(t *testing.T) {
-		hotelService := NewHotelService(nil, nil)
+		hotelService := NewHotelService(nil, nil, nil, nil, nil, nil, nil, Config{}, nil)
 		res, err := hotelService.Search(context.Background(), dto.SearchRequest{
 			Occupancies: "[",
 		})
@@ -42,7 +51,7 @@ func TestHotel_Search(t *testing.T) {
 		cliSearchReq, err := searchReq.Transform()
 		require.NoError(t, err)
 		cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(client.SearchResponse{}, assert.AnError)
-		hotelService := NewHotelService(cliMock, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
 		res, err := hotelService.Search(context.Background(), searchReq)
 		require.ErrorIs(t, err, assert.AnError)
 		require.Zero(t, res)
@@ -67,24 +76,103 @@ func TestHotel_Search(t *testing.T) {
 			var cliResp client.SearchResponse
 			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
 			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
-			hotelService := NewHotelService(cliMock, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
 			res, err := hotelService.Search(context.Background(), searchReq)
 			require.NoError(t, err)
 
 			expectedHotelInfos := dto.HotelInfos{
 				{
-					HotelID:  "264",
-					Currency: "EUR",
-					Price:    384.25,
+					HotelID:      "264",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        384.25,
+					NetPrice:     384.25,
+					SellingPrice: 384.25,
 				},
 				{
-					HotelID:  "77",
-					Currency: "EUR",
-					Price:    336.24,
+					HotelID:      "77",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        336.24,
+					NetPrice:     336.24,
+					SellingPrice: 336.24,
 				},
 			}
 			require.Equal(t, expectedHotelInfos, res.Data)
+			require.Equal(t, dto.DroppedHotels{{HotelID: "168", Supplier: "hotelbeds", Currency: "USD", Reason: dto.DropNoExchangeRate}},
+				res.Dropped)
+		})
+
+		t.Run("client success, convert currency", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "168,264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+				Detail:           dto.DetailRates,
+			}
+			cliSearchReq, err := searchReq.Transform()
+			require.NoError(t, err)
 
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
+			rates := staticRates{"USD": {"EUR": 0.9}}
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, rates, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+			require.Empty(t, res.Dropped)
+			require.Len(t, res.Data, 3)
+
+			converted := res.Data[0]
+			require.Equal(t, "168", converted.HotelID)
+			require.Equal(t, "EUR", converted.Currency)
+			require.Equal(t, 133.45, converted.Price)
+			require.Equal(t, &dto.Conversion{SourceCurrency: "USD", SourcePrice: 148.28, Rate: 0.9}, converted.Conversion)
+			rate := converted.Rooms[0].Rates[0]
+			require.Equal(t, 133.45, rate.Price)
+			require.Equal(t, 133.45, rate.CancellationPolicies[0].Amount)
+
+			require.Equal(t, 384.25, res.Data[1].Price)
+			require.Nil(t, res.Data[1].Conversion)
+		})
+
+		t.Run("client success, price bounds of converted prices", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "168,264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+				MaxPrice:         140,
+			}
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+					require.Nil(t, req.Filter)
+					return cliResp, nil
+				})
+			rates := staticRates{"USD": {"EUR": 0.9}}
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, rates, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+
+			// 148.28 USD is 133.45 EUR, below the bound in the requested currency.
+			require.Len(t, res.Data, 1)
+			require.Equal(t, "168", res.Data[0].HotelID)
+			require.Equal(t, 133.45, res.Data[0].Price)
+			require.Equal(t, 1, res.Total)
 		})
 
 		t.Run("client success, skip records when float parsing fails", func(t *testing.T) {
@@ -106,15 +194,214 @@ func TestHotel_Search(t *testing.T) {
 			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
 			cliResp.Hotels.Hotels[1].MinRate = "invalid rate"
 			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
-			hotelService := NewHotelService(cliMock, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+
+			expectedHotelInfos := dto.HotelInfos{
+				{
+					HotelID:      "77",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        336.24,
+					NetPrice:     336.24,
+					SellingPrice: 336.24,
+				},
+			}
+			require.Equal(t, expectedHotelInfos, res.Data)
+		})
+
+		t.Run("client success, detailed rates", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "168,264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+				Detail:           dto.DetailRates,
+			}
+			cliSearchReq, err := searchReq.Transform()
+			require.NoError(t, err)
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliResp.Hotels.Hotels[1].Rooms[0].Rates[0].RateClass = client.RateClassNonRefundable
+			cliResp.Hotels.Hotels[1].Rooms[0].Rates[1].Net = "invalid"
+			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+			require.Len(t, res.Data, 2)
+
+			upstreamRoom := cliResp.Hotels.Hotels[1].Rooms[0]
+			room := res.Data[0].Rooms[0]
+			require.Equal(t, upstreamRoom.Code, room.Code)
+			require.Equal(t, upstreamRoom.Name, room.Name)
+			require.Len(t, room.Rates, len(upstreamRoom.Rates)-1)
+
+			rate := room.Rates[0]
+			require.Equal(t, upstreamRoom.Rates[0].RateKey, rate.RateKey)
+			require.Equal(t, upstreamRoom.Rates[0].BoardCode, rate.BoardCode)
+			require.False(t, rate.Refundable)
+			require.Len(t, rate.CancellationPolicies, len(upstreamRoom.Rates[0].CancellationPolicies))
+			require.Len(t, rate.Taxes, len(upstreamRoom.Rates[0].Taxes.Taxes))
+			require.True(t, room.Rates[1].Refundable)
+		})
+
+		t.Run("client success, no detail by default", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "168,264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+			}
+			cliSearchReq, err := searchReq.Transform()
+			require.NoError(t, err)
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+			for _, hotelInfo := range res.Data {
+				require.Nil(t, hotelInfo.Rooms)
+			}
+		})
+
+		t.Run("client success, enriched from directory", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+			}
+			cliSearchReq, err := searchReq.Transform()
+			require.NoError(t, err)
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
+			directory := staticDirectory{264: {Name: "Hotel Bellevue", Category: "4EST", StarRating: 4}}
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, directory, nil, nil, Config{}, nil)
 			res, err := hotelService.Search(context.Background(), searchReq)
 			require.NoError(t, err)
 
 			expectedHotelInfos := dto.HotelInfos{
 				{
-					HotelID:  "77",
-					Currency: "EUR",
-					Price:    336.24,
+					HotelID:      "264",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        384.25,
+					NetPrice:     384.25,
+					SellingPrice: 384.25,
+					Name:         "Hotel Bellevue",
+					StarRating:   4,
+				},
+				{
+					HotelID:      "77",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        336.24,
+					NetPrice:     336.24,
+					SellingPrice: 336.24,
+				},
+			}
+			require.Equal(t, expectedHotelInfos, res.Data)
+		})
+
+		t.Run("client success, distance from the search center", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			latitude, longitude, radius := 39.537486363459, 2.4531088090492, 20.0
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				Latitude:         &latitude,
+				Longitude:        &longitude,
+				Radius:           &radius,
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+			}
+			cliSearchReq, err := searchReq.Transform()
+			require.NoError(t, err)
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+
+			require.Len(t, res.Data, 2)
+			require.Equal(t, "264", res.Data[0].HotelID)
+			require.Equal(t, 0.0, *res.Data[0].Distance)
+			require.Equal(t, "77", res.Data[1].HotelID)
+			require.Equal(t, 12.23, *res.Data[1].Distance)
+		})
+
+		t.Run("client success, filters sent to hotelbeds and refundable rates only", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			searchReq := dto.SearchRequest{
+				Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+				HotelIds:         "168,264,77",
+				CheckIn:          "2024-07-15",
+				CheckOut:         "2024-07-16",
+				Currency:         "EUR",
+				GuestNationality: "ES",
+				Boards:           "RO,BB",
+				RefundableOnly:   true,
+				MaxPrice:         1000,
+				MinCategory:      3,
+				PaymentType:      client.PaymentAtWeb,
+			}
+
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(context.Background(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+					require.Equal(t, &client.Boards{Board: []string{"RO", "BB"}, Included: true}, req.Boards)
+					require.Equal(t, &client.Filter{MinCategory: 3, PaymentType: client.PaymentAtWeb}, req.Filter)
+					return cliResp, nil
+				})
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+			res, err := hotelService.Search(context.Background(), searchReq)
+			require.NoError(t, err)
+
+			expectedHotelInfos := dto.HotelInfos{
+				{
+					HotelID:      "264",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        384.25,
+					NetPrice:     384.25,
+					SellingPrice: 384.25,
+				},
+				{
+					HotelID:      "77",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        341.04,
+					NetPrice:     341.04,
+					SellingPrice: 341.04,
 				},
 			}
 			require.Equal(t, expectedHotelInfos, res.Data)
@@ -140,7 +427,7 @@ func TestHotel_Search(t *testing.T) {
 			var cliResp client.SearchResponse
 			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
 			cliMock.EXPECT().Search(context.Background(), cliSearchReq).Return(cliResp, nil)
-			hotelService := NewHotelService(cliMock, nil)
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
 			res, err := hotelService.Search(context.Background(), searchReq)
 			require.NoError(t, err)
 
@@ -148,14 +435,20 @@ func TestHotel_Search(t *testing.T) {
 			require.NoError(t, err)
 			expectedHotelInfos := dto.HotelInfos{
 				{
-					HotelID:  "264",
-					Currency: "EUR",
-					Price:    384.25,
+					HotelID:      "264",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        384.25,
+					NetPrice:     384.25,
+					SellingPrice: 384.25,
 				},
 				{
-					HotelID:  "77",
-					Currency: "EUR",
-					Price:    336.24,
+					HotelID:      "77",
+					Supplier:     "hotelbeds",
+					Currency:     "EUR",
+					Price:        336.24,
+					NetPrice:     336.24,
+					SellingPrice: 336.24,
 				},
 			}
 
@@ -163,12 +456,17 @@ func TestHotel_Search(t *testing.T) {
 			hotelbedsResponse, err = json.Marshal(cliResp)
 			require.NoError(t, err)
 
+			payloads := dto.Supplier{
+				Name:     "hotelbeds",
+				Request:  json.RawMessage(expectedRequest),
+				Response: json.RawMessage(hotelbedsResponse),
+			}
 			expectedDtoResp := dto.SearchResponse{
-				Data: expectedHotelInfos,
-				Supplier: dto.Supplier{
-					Request:  json.RawMessage(expectedRequest),
-					Response: json.RawMessage(hotelbedsResponse),
-				},
+				Data:      expectedHotelInfos,
+				Supplier:  payloads,
+				Suppliers: dto.Suppliers{payloads},
+				Total:     2,
+				Dropped:   dto.DroppedHotels{{HotelID: "168", Supplier: "hotelbeds", Currency: "USD", Reason: dto.DropNoExchangeRate}},
 			}
 
 			require.Equal(t, expectedDtoResp, res)
@@ -176,3 +474,261 @@ func TestHotel_Search(t *testing.T) {
 		})
 	})
 }
+
+// hotelbedsSuppliers returns the Hotelbeds supplier searching with cli, as the only supplier.
+func hotelbedsSuppliers(cli client.HotelBeds) []supplier.Supplier {
+	return []supplier.Supplier{supplier.NewHotelbeds(cli, supplier.HotelbedsConfig{})}
+}
+
+// staticDirectory is a Directory backed by a map.
+type staticDirectory map[int]content.HotelSummary
+
+func (d staticDirectory) Lookup(code int) (content.HotelSummary, bool) {
+	summary, ok := d[code]
+	return summary, ok
+}
+
+// staticRates is an ExchangeRates backed by a map of rates by source and target currency.
+type staticRates map[string]map[string]float64
+
+func (r staticRates) Rate(from, to string) (float64, bool) {
+	rate, ok := r[from][to]
+	return rate, ok
+}
+
+func TestHotel_CheckRate(t *testing.T) {
+	checkRateReq := dto.CheckRateRequest{RateKeys: []string{"some-rate-key"}}
+
+	t.Run("hotelbeds client error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().CheckRate(context.Background(), checkRateReq.Transform()).Return(client.CheckRateResponse{}, assert.AnError)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.CheckRate(context.Background(), checkRateReq)
+		require.ErrorIs(t, err, assert.AnError)
+		require.Zero(t, res)
+	})
+
+	t.Run("other supplier requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		ctx := client.WithSupplierConfig(context.Background(), client.SupplierConfig{Supplier: "other"})
+		res, err := hotelService.CheckRate(ctx, checkRateReq)
+
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeUnknownSupplier, apiErr.Code())
+		require.Zero(t, res)
+	})
+
+	t.Run("market of the guest nationality", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		var cliResp client.CheckRateResponse
+		require.NoError(t, json.Unmarshal(hotelbedsCheckRateResponse, &cliResp))
+		cliMock.EXPECT().CheckRate(gomock.Any(), checkRateReq.Transform()).
+			DoAndReturn(func(ctx context.Context, _ client.CheckRateRequest) (client.CheckRateResponse, error) {
+				require.Equal(t, "GB", client.MarketFrom(ctx))
+				return cliResp, nil
+			})
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		req := checkRateReq
+		req.GuestNationality = "UK"
+		_, err := hotelService.CheckRate(context.Background(), req)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid total net", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		var cliResp client.CheckRateResponse
+		require.NoError(t, json.Unmarshal(hotelbedsCheckRateResponse, &cliResp))
+		cliResp.Hotel.TotalNet = "invalid"
+		cliMock.EXPECT().CheckRate(context.Background(), checkRateReq.Transform()).Return(cliResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.CheckRate(context.Background(), checkRateReq)
+		require.Error(t, err)
+		require.Zero(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		var cliResp client.CheckRateResponse
+		require.NoError(t, json.Unmarshal(hotelbedsCheckRateResponse, &cliResp))
+		cliMock.EXPECT().CheckRate(context.Background(), checkRateReq.Transform()).Return(cliResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.CheckRate(context.Background(), checkRateReq)
+		require.NoError(t, err)
+
+		rate := cliResp.Hotel.Rooms[0].Rates[0]
+		expectedInfo := dto.CheckRateInfo{
+			HotelID:  "264",
+			CheckIn:  "2024-07-15",
+			CheckOut: "2024-07-16",
+			Currency: "EUR",
+			NetPrice: 384.25,
+			Rates: dto.CheckedRates{
+				{
+					RateKey:      rate.RateKey,
+					RoomCode:     "DBL.ST",
+					RoomName:     "Double standard",
+					BoardCode:    "RO",
+					NetPrice:     384.25,
+					RateComments: rate.RateComments,
+					CancellationPolicies: dto.CancellationPolicies{
+						{Amount: 384.25, From: rate.CancellationPolicies[0].From},
+					},
+				},
+			},
+		}
+		require.Equal(t, expectedInfo, res.Data)
+		require.NotEmpty(t, res.Supplier.Request)
+		require.NotEmpty(t, res.Supplier.Response)
+	})
+}
+
+func TestHotel_Bookings(t *testing.T) {
+	var cliResp client.BookingResponse
+	require.NoError(t, json.Unmarshal(hotelbedsBookingResponse, &cliResp))
+
+	expectedInfo := dto.BookingInfo{
+		Reference:       "102-4256498",
+		ClientReference: "LITEAPI-0001",
+		Status:          "CONFIRMED",
+		CreationDate:    "2024-07-12",
+		Holder:          dto.Holder{FirstName: "Jane", LastName: "Doe"},
+		HotelID:         "264",
+		HotelName:       "Hotel Bellver",
+		CheckIn:         "2024-07-15",
+		CheckOut:        "2024-07-16",
+		Currency:        "EUR",
+		TotalNet:        384.25,
+		PendingAmount:   384.25,
+		Rooms: dto.BookedRooms{
+			{
+				Status:   "CONFIRMED",
+				RoomCode: "DBL.ST",
+				RoomName: "Double standard",
+				Guests: dto.Guests{
+					{Type: dto.GuestAdult, FirstName: "Jane", LastName: "Doe"},
+					{Type: dto.GuestChild, Age: 8, FirstName: "Tom", LastName: "Doe"},
+				},
+				Rates: dto.CheckedRates{
+					{
+						RoomCode:     "DBL.ST",
+						RoomName:     "Double standard",
+						BoardCode:    "RO",
+						NetPrice:     384.25,
+						RateComments: "Check-in hour 14:00-00:00.",
+						CancellationPolicies: dto.CancellationPolicies{
+							{Amount: 384.25, From: cliResp.Booking.Hotel.Rooms[0].Rates[0].CancellationPolicies[0].From},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("book", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		bookingReq := dto.BookingRequest{
+			Holder: dto.Holder{FirstName: "Jane", LastName: "Doe"},
+			Rooms: dto.BookingRooms{
+				{
+					RateKey: "some-rate-key",
+					Guests: dto.Guests{
+						{Type: dto.GuestAdult, FirstName: "Jane", LastName: "Doe"},
+						{Type: dto.GuestChild, Age: 8, FirstName: "Tom", LastName: "Doe"},
+					},
+				},
+			},
+			ClientReference: "LITEAPI-0001",
+		}
+		cliMock.EXPECT().Book(context.Background(), bookingReq.Transform()).Return(cliResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.Book(context.Background(), bookingReq)
+		require.NoError(t, err)
+		require.Equal(t, expectedInfo, res.Data)
+	})
+
+	t.Run("book client error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().Book(context.Background(), gomock.Any()).Return(client.BookingResponse{}, assert.AnError)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.Book(context.Background(), dto.BookingRequest{})
+		require.ErrorIs(t, err, assert.AnError)
+		require.Zero(t, res)
+	})
+
+	t.Run("booking detail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().BookingDetail(context.Background(), "102-4256498").Return(cliResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.BookingDetail(context.Background(), dto.BookingDetailRequest{Reference: "102-4256498"})
+		require.NoError(t, err)
+		require.Equal(t, expectedInfo, res.Data)
+	})
+
+	t.Run("cancel booking simulation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().CancelBooking(context.Background(), "102-4256498", client.CancellationSimulation).Return(cliResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.CancelBooking(context.Background(), dto.CancelBookingRequest{
+			Reference: "102-4256498",
+			Mode:      dto.CancelModeSimulation,
+		})
+		require.NoError(t, err)
+		require.Equal(t, expectedInfo, res.Data)
+	})
+
+	t.Run("cancel booking with the market of the guest nationality", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().CancelBooking(gomock.Any(), "102-4256498", client.CancellationConfirm).
+			DoAndReturn(func(ctx context.Context, _ string, _ client.CancellationFlag) (client.BookingResponse, error) {
+				require.Equal(t, "ES", client.MarketFrom(ctx))
+				return cliResp, nil
+			})
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		_, err := hotelService.CancelBooking(context.Background(), dto.CancelBookingRequest{
+			Reference:        "102-4256498",
+			GuestNationality: "ES",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid rate price", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		var invalidResp client.BookingResponse
+		require.NoError(t, json.Unmarshal(hotelbedsBookingResponse, &invalidResp))
+		invalidResp.Booking.Hotel.Rooms[0].Rates[0].Net = "invalid"
+		cliMock.EXPECT().BookingDetail(context.Background(), "102-4256498").Return(invalidResp, nil)
+		hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.BookingDetail(context.Background(), dto.BookingDetailRequest{Reference: "102-4256498"})
+		require.Error(t, err)
+		require.Zero(t, res)
+	})
+}
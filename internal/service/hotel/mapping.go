@@ -0,0 +1,79 @@
+package hotel
+
+import (
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	"lite-api/internal/supplier"
+	"strconv"
+)
+
+// Mapper translates lite API hotel ids to the hotel codes of suppliers and back.
+type Mapper interface {
+	Code(supplier string, hotelID int) (int, bool)
+	HotelID(supplier string, code int) (int, bool)
+}
+
+// mapRequest returns searchReq with its hotel ids translated to the codes of supplierName, along with the ids
+// without a code. Requests scoped otherwise, or searched without a Mapper, are returned unchanged.
+func (t *HotelS) mapRequest(supplierName string, searchReq client.SearchRequest) (client.SearchRequest, []int) {
+	if t.mapper == nil || searchReq.Hotels == nil {
+		return searchReq, nil
+	}
+
+	var codes, unmapped []int
+	for _, hotelID := range searchReq.Hotels.Hotel {
+		code, ok := t.mapper.Code(supplierName, hotelID)
+		if !ok {
+			unmapped = append(unmapped, hotelID)
+			continue
+		}
+
+		codes = append(codes, code)
+	}
+
+	searchReq.Hotels = &client.HotelIds{Hotel: codes}
+	return searchReq, unmapped
+}
+
+// mapAvailability translates the hotel codes of availability back to lite API hotel ids. Hotels whose code maps to
+// no hotel id are removed and returned as dropped.
+func (t *HotelS) mapAvailability(supplierName string, availability supplier.Availability) (supplier.Availability,
+	dto.DroppedHotels) {
+	if t.mapper == nil {
+		return availability, nil
+	}
+
+	var dropped dto.DroppedHotels
+	hotels := make(supplier.Hotels, 0, len(availability.Hotels))
+	for _, hotel := range availability.Hotels {
+		code, err := strconv.Atoi(hotel.ID)
+		hotelID, ok := t.mapper.HotelID(supplierName, code)
+		if err != nil || !ok {
+			dropped = append(dropped, dto.DroppedHotel{
+				HotelID:  hotel.ID,
+				Supplier: supplierName,
+				Currency: hotel.Currency,
+				Reason:   dto.DropUnmappedHotel,
+			})
+			continue
+		}
+
+		hotel.ID = strconv.Itoa(hotelID)
+		hotels = append(hotels, hotel)
+	}
+
+	var failures supplier.Failures
+	for _, failure := range availability.Failures {
+		hotelIDs := make([]int, len(failure.HotelIds))
+		for i, code := range failure.HotelIds {
+			// Failed codes were all mapped from the requested hotel ids.
+			hotelIDs[i], _ = t.mapper.HotelID(supplierName, code)
+		}
+
+		failure.HotelIds = hotelIDs
+		failures = append(failures, failure)
+	}
+
+	availability.Hotels, availability.Failures = hotels, failures
+	return availability, dropped
+}
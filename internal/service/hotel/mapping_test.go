@@ -0,0 +1,130 @@
+package hotel
+
+import (
+	"context"
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	"lite-api/internal/mapping"
+	"lite-api/internal/supplier"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoSupplier is a supplier.Supplier finding every requested hotel, plus the hotels of extra.
+type echoSupplier struct {
+	name  string
+	extra []int
+	// searched is the last request searched.
+	searched *client.SearchRequest
+}
+
+func (e echoSupplier) Name() string { return e.name }
+
+func (e echoSupplier) Capabilities() supplier.Capabilities {
+	return supplier.Capabilities{supplier.CapabilityHotelIds, supplier.CapabilityDestination}
+}
+
+func (e echoSupplier) Search(_ context.Context, searchReq client.SearchRequest) (supplier.Availability, error) {
+	*e.searched = searchReq
+
+	var availability supplier.Availability
+	var codes []int
+	if searchReq.Hotels != nil {
+		codes = searchReq.Hotels.Hotel
+	}
+
+	for _, code := range append(codes, e.extra...) {
+		availability.Hotels = append(availability.Hotels, supplier.Hotel{
+			ID:       strconv.Itoa(code),
+			Currency: "EUR",
+			MinRate:  100,
+		})
+	}
+
+	if len(codes) > 1 {
+		availability.Failures = supplier.Failures{{Batch: 1, HotelIds: codes[1:], Err: assert.AnError}}
+	}
+
+	return availability, nil
+}
+
+func TestHotel_SearchMapping(t *testing.T) {
+	table, err := mapping.NewTable([]mapping.Row{
+		{HotelID: 129410, Supplier: "hotelbeds", Code: 264},
+		{HotelID: 105360, Supplier: "hotelbeds", Code: 77},
+		{HotelID: 129410, Supplier: "other", Code: 9},
+	})
+	require.NoError(t, err)
+
+	searchReq := dto.SearchRequest{
+		Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+		HotelIds:         "129410,105360,106101",
+		CheckIn:          "2024-07-15",
+		CheckOut:         "2024-07-16",
+		Currency:         "EUR",
+		GuestNationality: "US",
+	}
+
+	t.Run("hotel ids are translated both ways", func(t *testing.T) {
+		var hotelbedsReq, otherReq client.SearchRequest
+		hotelbeds := echoSupplier{name: "hotelbeds", extra: []int{168}, searched: &hotelbedsReq}
+		other := echoSupplier{name: "other", searched: &otherReq}
+
+		hotelService := NewHotelService(nil, []supplier.Supplier{hotelbeds, other}, table, nil, nil, nil, nil,
+			Config{}, nil)
+		res, err := hotelService.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+
+		require.Equal(t, []int{264, 77}, hotelbedsReq.Hotels.Hotel)
+		require.Equal(t, []int{9}, otherReq.Hotels.Hotel)
+
+		var hotels []string
+		for _, info := range res.Data {
+			hotels = append(hotels, info.Supplier+":"+info.HotelID)
+		}
+		require.Equal(t, []string{"hotelbeds:129410", "hotelbeds:105360", "other:129410"}, hotels)
+		require.Equal(t, dto.BatchFailures{{Supplier: "hotelbeds", Batch: 1, HotelIds: []int{105360},
+			Error: dto.NewErrorDetail(assert.AnError)}}, res.Failures)
+		require.Equal(t, dto.DroppedHotels{
+			{HotelID: "168", Supplier: "hotelbeds", Currency: "EUR", Reason: dto.DropUnmappedHotel},
+		}, res.Dropped)
+		require.Equal(t, dto.UnmappedHotels{
+			{Supplier: "hotelbeds", HotelIds: []int{106101}},
+			{Supplier: "other", HotelIds: []int{105360, 106101}},
+		}, res.Unmapped)
+	})
+
+	t.Run("suppliers without mapped hotel are not searched", func(t *testing.T) {
+		var hotelbedsReq client.SearchRequest
+		hotelbeds := echoSupplier{name: "hotelbeds", searched: &hotelbedsReq}
+
+		hotelService := NewHotelService(nil, []supplier.Supplier{hotelbeds}, table, nil, nil, nil, nil, Config{}, nil)
+		unmappedReq := searchReq
+		unmappedReq.HotelIds = "106101"
+		res, err := hotelService.Search(context.Background(), unmappedReq)
+		require.NoError(t, err)
+
+		require.Zero(t, hotelbedsReq)
+		require.Empty(t, res.Data)
+		require.Equal(t, dto.UnmappedHotels{{Supplier: "hotelbeds", HotelIds: []int{106101}}}, res.Unmapped)
+	})
+
+	t.Run("destination searches are sent as they are", func(t *testing.T) {
+		var hotelbedsReq client.SearchRequest
+		hotelbeds := echoSupplier{name: "hotelbeds", extra: []int{264}, searched: &hotelbedsReq}
+
+		hotelService := NewHotelService(nil, []supplier.Supplier{hotelbeds}, table, nil, nil, nil, nil, Config{}, nil)
+		destinationReq := searchReq
+		destinationReq.HotelIds, destinationReq.Destination = "", "PMI"
+		res, err := hotelService.Search(context.Background(), destinationReq)
+		require.NoError(t, err)
+
+		require.Equal(t, "PMI", hotelbedsReq.Destination.Code)
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "129410", res.Data[0].HotelID)
+		require.Empty(t, res.Unmapped)
+	})
+}
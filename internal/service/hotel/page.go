@@ -0,0 +1,188 @@
+package hotel
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"lite-api/internal/client"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"sync"
+	"time"
+
+	"go.nhat.io/clock"
+)
+
+const (
+	defaultCursorTTL      = 10 * time.Minute
+	defaultMaxCursorBytes = 64 << 20
+
+	// ErrCodeCursorExpired is the code of the error returned for a cursor whose search is no longer kept.
+	ErrCodeCursorExpired = "cursor_expired"
+	// ErrCodeCursorMismatch is the code of the error returned for a cursor used with another search.
+	ErrCodeCursorMismatch = "cursor_mismatch"
+)
+
+// storedSearch is the full response of a paginated search.
+type storedSearch struct {
+	id          string
+	fingerprint string
+	res         dto.SearchResponse
+	size        int
+	expiresAt   time.Time
+}
+
+// pageStore keeps the responses of paginated searches for ttl, so that the following pages are cut from the same
+// supplier response. Searches expire in the order they were stored since they share the same ttl, the oldest are
+// dropped first once the stored responses exceed maxBytes.
+type pageStore struct {
+	ttl      time.Duration
+	maxBytes int
+	clock    clock.Clock
+
+	mu       sync.Mutex
+	size     int
+	searches map[string]storedSearch
+	order    []string
+}
+
+func newPageStore(ttl time.Duration, maxBytes int, clock clock.Clock) *pageStore {
+	return &pageStore{
+		ttl:      ttl,
+		maxBytes: maxBytes,
+		clock:    clock,
+		searches: make(map[string]storedSearch),
+	}
+}
+
+// put stores res and returns its id, false if res alone exceeds maxBytes.
+func (p *pageStore) put(fingerprint string, res dto.SearchResponse) (string, bool, error) {
+	// The encoded size is used as an estimate of the memory held by the response, as for the availability cache.
+	payload, err := json.Marshal(res)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(payload) > p.maxBytes {
+		return "", false, nil
+	}
+
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", false, err
+	}
+
+	id := hex.EncodeToString(raw[:])
+	now := p.clock.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.order) > 0 {
+		oldest := p.searches[p.order[0]]
+		if p.size+len(payload) <= p.maxBytes && now.Before(oldest.expiresAt) {
+			break
+		}
+
+		delete(p.searches, oldest.id)
+		p.size -= oldest.size
+		p.order = p.order[1:]
+	}
+
+	p.searches[id] = storedSearch{
+		id:          id,
+		fingerprint: fingerprint,
+		res:         res,
+		size:        len(payload),
+		expiresAt:   now.Add(p.ttl),
+	}
+	p.size += len(payload)
+	p.order = append(p.order, id)
+
+	return id, true, nil
+}
+
+// get returns the search stored with id, false if it expired.
+func (p *pageStore) get(id string) (storedSearch, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	search, ok := p.searches[id]
+	if !ok || !p.clock.Now().Before(search.expiresAt) {
+		return storedSearch{}, false
+	}
+
+	return search, true
+}
+
+// paginate returns the first page of res, storing res when it has more than one page. The supplier payloads are
+// only returned with the first page, they are not stored. res is returned whole when it is too large to be stored.
+func (t *HotelS) paginate(ctx context.Context, req dto.SearchRequest, res dto.SearchResponse) (dto.SearchResponse,
+	error) {
+	if req.Limit == 0 || len(res.Data) <= req.Limit {
+		return res, nil
+	}
+
+	stored := res
+	stored.Supplier, stored.Suppliers = dto.Supplier{}, nil
+	id, ok, err := t.pages.put(fingerprint(ctx, req), stored)
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	if !ok {
+		return res, nil
+	}
+
+	return page(res, id, 0, req.Limit), nil
+}
+
+// searchPage returns the page of req.Cursor from the stored search.
+func (t *HotelS) searchPage(ctx context.Context, req dto.SearchRequest) (dto.SearchResponse, error) {
+	cursor, err := dto.ParseCursor(req.Cursor)
+	if err != nil {
+		return dto.SearchResponse{}, err
+	}
+
+	search, ok := t.pages.get(cursor.SearchID)
+	if !ok {
+		return dto.SearchResponse{}, liteapierrors.NewNotFoundErr(ErrCodeCursorExpired,
+			"the search of the cursor expired, search again without cursor")
+	}
+
+	if search.fingerprint != fingerprint(ctx, req) {
+		return dto.SearchResponse{}, liteapierrors.NewInvalidRequestErr(ErrCodeCursorMismatch,
+			"the cursor belongs to a search with other parameters")
+	}
+
+	limit := cursor.Limit
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	return page(search.res, search.id, cursor.Offset, limit), nil
+}
+
+// page returns the limit results of res from offset, with the cursor of the next page unless it is the last one.
+func page(res dto.SearchResponse, id string, offset, limit int) dto.SearchResponse {
+	start := min(offset, len(res.Data))
+	end := min(offset+limit, len(res.Data))
+	if end < len(res.Data) {
+		res.NextCursor = dto.Cursor{SearchID: id, Offset: end, Limit: limit}.Encode()
+	}
+
+	res.Data = res.Data[start:end:end]
+	return res
+}
+
+// fingerprint identifies the parameters of a search, regardless of the page requested. Searches of other suppliers,
+// environments or accounts of the supplier config of ctx have other fingerprints.
+func fingerprint(ctx context.Context, req dto.SearchRequest) string {
+	req.Cursor, req.Limit = "", 0
+	payload, _ := json.Marshal(req)
+	cfg := client.SupplierConfigFrom(ctx)
+	hash := sha256.Sum256(append(payload, cfg.Supplier+"/"+cfg.Partition()...))
+	return hex.EncodeToString(hash[:])
+}
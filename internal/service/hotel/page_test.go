@@ -0,0 +1,202 @@
+package hotel
+
+import (
+	"context"
+	"encoding/json"
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a clock.Clock which only moves when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+func (m *manualClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func TestHotel_Paginate(t *testing.T) {
+	req := dto.SearchRequest{
+		Occupancies:      `[{"Rooms":1,"Adults":2,"Children":0}]`,
+		HotelIds:         "1,2,3",
+		CheckIn:          "2024-07-15",
+		CheckOut:         "2024-07-16",
+		Currency:         "EUR",
+		GuestNationality: "ES",
+		Sort:             "price",
+		Limit:            2,
+	}
+	res := dto.SearchResponse{
+		Data: []dto.HotelInfo{
+			{HotelID: "1", Price: 10},
+			{HotelID: "2", Price: 20},
+			{HotelID: "3", Price: 30},
+		},
+		Total: 3,
+		Sort:  "price",
+	}
+
+	// The store keeps two searches of res.
+	payload, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	newService := func() (*HotelS, *manualClock) {
+		clk := &manualClock{now: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)}
+		hotelService := NewHotelService(nil, nil, nil, nil, nil, nil, nil, Config{}, nil)
+		hotelService.pages = newPageStore(time.Minute, 2*len(payload), clk)
+		return hotelService, clk
+	}
+
+	ids := func(res dto.SearchResponse) []string {
+		ids := make([]string, 0, len(res.Data))
+		for _, hotel := range res.Data {
+			ids = append(ids, hotel.HotelID)
+		}
+		return ids
+	}
+
+	t.Run("single page", func(t *testing.T) {
+		hotelService, _ := newService()
+		req := req
+		req.Limit = 3
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		require.Equal(t, res, first)
+	})
+
+	t.Run("follows the cursor", func(t *testing.T) {
+		hotelService, _ := newService()
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, ids(first))
+		require.Equal(t, 3, first.Total)
+		require.NotEmpty(t, first.NextCursor)
+
+		next := req
+		next.Cursor = first.NextCursor
+		second, err := hotelService.Search(context.Background(), next)
+		require.NoError(t, err)
+		require.Equal(t, []string{"3"}, ids(second))
+		require.Equal(t, 3, second.Total)
+		require.Empty(t, second.NextCursor)
+	})
+
+	t.Run("supplier payloads only with the first page", func(t *testing.T) {
+		hotelService, _ := newService()
+		payloads := dto.Supplier{
+			Name:     "hotelbeds",
+			Request:  json.RawMessage(`{"stay":{}}`),
+			Response: json.RawMessage(`{"hotels":{}}`),
+		}
+		res := res
+		res.Supplier, res.Suppliers = payloads, dto.Suppliers{payloads}
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		require.Equal(t, payloads, first.Supplier)
+		require.Equal(t, dto.Suppliers{payloads}, first.Suppliers)
+
+		next := req
+		next.Cursor = first.NextCursor
+		second, err := hotelService.Search(context.Background(), next)
+		require.NoError(t, err)
+		require.Equal(t, []string{"3"}, ids(second))
+		require.Zero(t, second.Supplier)
+		require.Empty(t, second.Suppliers)
+	})
+
+	t.Run("search too large to be kept", func(t *testing.T) {
+		hotelService, _ := newService()
+		hotelService.pages.maxBytes = len(payload) - 1
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		require.Equal(t, res, first)
+	})
+
+	t.Run("limit of the request overrides the cursor", func(t *testing.T) {
+		hotelService, _ := newService()
+		req := req
+		req.Limit = 1
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+
+		next := req
+		next.Cursor = first.NextCursor
+		next.Limit = 2
+		second, err := hotelService.Search(context.Background(), next)
+		require.NoError(t, err)
+		require.Equal(t, []string{"2", "3"}, ids(second))
+		require.Empty(t, second.NextCursor)
+	})
+
+	t.Run("expired cursor", func(t *testing.T) {
+		hotelService, clk := newService()
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		clk.Advance(time.Minute)
+
+		next := req
+		next.Cursor = first.NextCursor
+		_, err = hotelService.Search(context.Background(), next)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeCursorExpired, apiErr.Code())
+		require.Equal(t, liteapierrors.KindNotFound, apiErr.Kind())
+	})
+
+	t.Run("evicted cursor", func(t *testing.T) {
+		hotelService, _ := newService()
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+		for range 2 {
+			_, err = hotelService.paginate(context.Background(), req, res)
+			require.NoError(t, err)
+		}
+
+		next := req
+		next.Cursor = first.NextCursor
+		_, err = hotelService.Search(context.Background(), next)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeCursorExpired, apiErr.Code())
+	})
+
+	t.Run("cursor of another search", func(t *testing.T) {
+		hotelService, _ := newService()
+
+		first, err := hotelService.paginate(context.Background(), req, res)
+		require.NoError(t, err)
+
+		next := req
+		next.Cursor = first.NextCursor
+		next.Sort = "-price"
+		_, err = hotelService.Search(context.Background(), next)
+		var apiErr *liteapierrors.APIErr
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, ErrCodeCursorMismatch, apiErr.Code())
+		require.Equal(t, liteapierrors.KindInvalidRequest, apiErr.Kind())
+	})
+}
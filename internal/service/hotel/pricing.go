@@ -0,0 +1,58 @@
+package hotel
+
+import (
+	"context"
+	"lite-api/internal/dto"
+	"lite-api/internal/pricing"
+)
+
+// Pricer marks the net prices of the supplier up to selling prices.
+type Pricer interface {
+	Quote(target pricing.Target, net float64) pricing.Quote
+}
+
+// quote returns the selling price of net for target, net itself without Pricer.
+func (t *HotelS) quote(target pricing.Target, net float64) pricing.Quote {
+	if t.pricer == nil {
+		return pricing.Quote{Net: net, Selling: net}
+	}
+
+	return t.pricer.Quote(target, net)
+}
+
+// priceRooms sets the net and selling prices of the rates of rooms in place, from their net Price.
+func (t *HotelS) priceRooms(rooms dto.RoomInfos, target pricing.Target) {
+	for _, room := range rooms {
+		for i := range room.Rates {
+			rate := &room.Rates[i]
+			quote := t.quote(target, rate.Price)
+			rate.NetPrice, rate.SellingPrice, rate.Price = quote.Net, quote.Selling, quote.Selling
+		}
+	}
+}
+
+// MatchPricingRule returns the selling price of the net price of the request and the pricing rule it matched.
+func (t *HotelS) MatchPricingRule(_ context.Context, req dto.PricingMatchRequest) (dto.PricingMatchResponse, error) {
+	quote := t.quote(pricing.Target{
+		Destination: req.Destination,
+		Stars:       req.Stars,
+		Channel:     req.Channel,
+		CheckIn:     req.CheckIn.String(),
+	}, req.NetPrice)
+
+	match := dto.PricingMatch{
+		NetPrice:     quote.Net,
+		SellingPrice: quote.Selling,
+	}
+
+	if quote.Rule != nil {
+		match.Rule = &dto.PricingRule{
+			Name:        quote.Rule.Name,
+			Priority:    quote.Rule.Priority,
+			MarkupType:  quote.Rule.Markup.Type,
+			MarkupValue: quote.Rule.Markup.Value,
+		}
+	}
+
+	return dto.PricingMatchResponse{Data: match}, nil
+}
@@ -0,0 +1,133 @@
+package hotel
+
+import (
+	"context"
+	"encoding/json"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	"lite-api/internal/dto"
+	"lite-api/internal/pricing"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRules(t *testing.T) *pricing.Rules {
+	t.Helper()
+	rules, err := pricing.NewRules([]pricing.Rule{
+		{
+			Name:   "default",
+			Markup: pricing.Markup{Type: pricing.MarkupPercentage, Value: 10},
+		},
+		{
+			Name:     "b2b",
+			Priority: 10,
+			Match:    pricing.Match{Channels: []string{"b2b"}},
+			Markup:   pricing.Markup{Type: pricing.MarkupFixed, Value: 5},
+			Rounding: pricing.Rounding{Mode: pricing.RoundUp, Increment: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	return rules
+}
+
+func TestHotel_SearchPricing(t *testing.T) {
+	searchReq := dto.SearchRequest{
+		Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+		HotelIds:         "264,77",
+		CheckIn:          "2024-07-15",
+		CheckOut:         "2024-07-16",
+		Currency:         "EUR",
+		GuestNationality: "ES",
+		Detail:           dto.DetailRates,
+		Sort:             dto.SortPrice,
+	}
+
+	tests := []struct {
+		channel      string
+		wantHotels   []string
+		wantSelling  []float64
+		wantRateSell float64
+	}{
+		{"", []string{"77", "264"}, []float64{369.86, 422.68}, 422.68},
+		{"b2b", []string{"77", "264"}, []float64{342, 390}, 390},
+	}
+
+	for _, tt := range tests {
+		t.Run("channel "+tt.channel, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+			var cliResp client.SearchResponse
+			require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+			cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Return(cliResp, nil)
+
+			req := searchReq
+			req.Channel = tt.channel
+			hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, newRules(t), Config{}, nil)
+			res, err := hotelService.Search(context.Background(), req)
+			require.NoError(t, err)
+			require.Len(t, res.Data, 2)
+
+			for i, hotel := range res.Data {
+				require.Equal(t, tt.wantHotels[i], hotel.HotelID)
+				require.Equal(t, tt.wantSelling[i], hotel.SellingPrice)
+				require.Equal(t, hotel.SellingPrice, hotel.Price)
+			}
+			require.Equal(t, 336.24, res.Data[0].NetPrice)
+
+			rate := res.Data[1].Rooms[0].Rates[0]
+			require.Equal(t, 384.25, rate.NetPrice)
+			require.Equal(t, tt.wantRateSell, rate.SellingPrice)
+			require.Equal(t, rate.SellingPrice, rate.Price)
+		})
+	}
+}
+
+func TestHotel_SearchPricingPriceBounds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+	var cliResp client.SearchResponse
+	require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+	cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Return(cliResp, nil)
+
+	// Both net prices, 336.24 and 384.25, are below 400 while the 10% markup sells 264 at 422.68.
+	hotelService := NewHotelService(cliMock, hotelbedsSuppliers(cliMock), nil, nil, nil, nil, newRules(t), Config{}, nil)
+	res, err := hotelService.Search(context.Background(), dto.SearchRequest{
+		Occupancies:      `[{"Rooms":1,"Adults":2,"Children":1}]`,
+		HotelIds:         "264,77",
+		CheckIn:          "2024-07-15",
+		CheckOut:         "2024-07-16",
+		Currency:         "EUR",
+		GuestNationality: "ES",
+		MaxPrice:         400,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	require.Equal(t, "77", res.Data[0].HotelID)
+	require.Equal(t, 369.86, res.Data[0].SellingPrice)
+}
+
+func TestHotel_MatchPricingRule(t *testing.T) {
+	t.Run("matching rule", func(t *testing.T) {
+		hotelService := NewHotelService(nil, nil, nil, nil, nil, nil, newRules(t), Config{}, nil)
+		res, err := hotelService.MatchPricingRule(context.Background(), dto.PricingMatchRequest{
+			Channel:  "b2b",
+			NetPrice: 99.5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, dto.PricingMatch{
+			NetPrice:     99.5,
+			SellingPrice: 105,
+			Rule:         &dto.PricingRule{Name: "b2b", Priority: 10, MarkupType: pricing.MarkupFixed, MarkupValue: 5},
+		}, res.Data)
+	})
+
+	t.Run("without rules", func(t *testing.T) {
+		hotelService := NewHotelService(nil, nil, nil, nil, nil, nil, nil, Config{}, nil)
+		res, err := hotelService.MatchPricingRule(context.Background(), dto.PricingMatchRequest{NetPrice: 99.5})
+		require.NoError(t, err)
+		require.Equal(t, dto.PricingMatch{NetPrice: 99.5, SellingPrice: 99.5}, res.Data)
+	})
+}
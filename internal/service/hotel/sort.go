@@ -0,0 +1,57 @@
+package hotel
+
+import (
+	"cmp"
+	"lite-api/internal/dto"
+	"slices"
+	"strings"
+)
+
+// searchResult is a hotel of a search along with the values it may be sorted by.
+type searchResult struct {
+	info  dto.HotelInfo
+	name  string
+	stars float64
+}
+
+// sortResults sorts results by the sort key of req, keeping supplier order between equal results. Results without
+// distance come last whatever the order.
+func sortResults(results []searchResult, req dto.SearchRequest) {
+	if req.Sort == "" {
+		return
+	}
+
+	key, descending := req.SortKey()
+	compare := func(a, b searchResult) int {
+		switch key {
+		case dto.SortPrice:
+			return cmp.Compare(a.info.Price, b.info.Price)
+		case dto.SortCategory:
+			return cmp.Compare(a.stars, b.stars)
+		case dto.SortName:
+			return cmp.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+		default:
+			return cmp.Compare(*a.info.Distance, *b.info.Distance)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b searchResult) int {
+		if key == dto.SortDistance && (a.info.Distance == nil || b.info.Distance == nil) {
+			return cmp.Compare(boolToInt(a.info.Distance == nil), boolToInt(b.info.Distance == nil))
+		}
+
+		if descending {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
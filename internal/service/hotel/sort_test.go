@@ -0,0 +1,46 @@
+package hotel
+
+import (
+	"lite-api/internal/dto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortResults(t *testing.T) {
+	distance := func(d float64) *float64 { return &d }
+	results := func() []searchResult {
+		return []searchResult{
+			{info: dto.HotelInfo{HotelID: "1", Price: 120, Distance: distance(3.5)}, name: "Casa Azul", stars: 3},
+			{info: dto.HotelInfo{HotelID: "2", Price: 80}, name: "bristol", stars: 4},
+			{info: dto.HotelInfo{HotelID: "3", Price: 80, Distance: distance(0.8)}, name: "Alameda", stars: 5},
+		}
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"1", "2", "3"}},
+		{"price", []string{"2", "3", "1"}},
+		{"-price", []string{"1", "2", "3"}},
+		{"category", []string{"1", "2", "3"}},
+		{"-category", []string{"3", "2", "1"}},
+		{"name", []string{"3", "2", "1"}},
+		{"distance", []string{"3", "1", "2"}},
+		{"-distance", []string{"1", "3", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			got := results()
+			sortResults(got, dto.SearchRequest{Sort: tt.sort})
+
+			ids := make([]string, 0, len(got))
+			for _, result := range got {
+				ids = append(ids, result.info.HotelID)
+			}
+			require.Equal(t, tt.want, ids)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package hotel
+
+import (
+	"lite-api/internal/dto"
+	"lite-api/internal/supplier"
+)
+
+// roomInfos converts the rooms of a supplier to the lite API contract, priced at their net price.
+func roomInfos(rooms supplier.Rooms) dto.RoomInfos {
+	infos := make(dto.RoomInfos, len(rooms))
+	for i, room := range rooms {
+		rates := make(dto.RateInfos, len(room.Rates))
+		for j, rate := range room.Rates {
+			taxes := make(dto.TaxInfos, len(rate.Taxes))
+			for k, tax := range rate.Taxes {
+				taxes[k] = dto.TaxInfo{
+					Included: tax.Included,
+					Amount:   tax.Amount,
+					Currency: tax.Currency,
+				}
+			}
+
+			rates[j] = dto.RateInfo{
+				RateKey:              rate.RateKey,
+				RateClass:            rate.RateClass,
+				RateType:             rate.RateType,
+				BoardCode:            rate.BoardCode,
+				BoardName:            rate.BoardName,
+				PaymentType:          rate.PaymentType,
+				Refundable:           rate.Refundable,
+				Allotment:            rate.Allotment,
+				Price:                rate.Net,
+				CancellationPolicies: cancellationPolicies(rate.CancellationPolicies),
+				Taxes:                taxes,
+				TaxesIncluded:        rate.TaxesIncluded,
+			}
+		}
+
+		infos[i] = dto.RoomInfo{
+			Code:  room.Code,
+			Name:  room.Name,
+			Rates: rates,
+		}
+	}
+
+	return infos
+}
+
+// cancellationPolicies converts the cancellation policies of a supplier to the lite API contract.
+func cancellationPolicies(policies supplier.CancellationPolicies) dto.CancellationPolicies {
+	converted := make(dto.CancellationPolicies, len(policies))
+	for i, policy := range policies {
+		converted[i] = dto.CancellationPolicy{
+			Amount: policy.Amount,
+			From:   policy.From,
+		}
+	}
+
+	return converted
+}
+
+// batchFailure converts a failed batch of supplierName to the lite API contract.
+func batchFailure(supplierName string, failure supplier.Failure) dto.BatchFailure {
+	return dto.BatchFailure{
+		Supplier: supplierName,
+		Batch:    failure.Batch,
+		HotelIds: failure.HotelIds,
+		Error:    dto.NewErrorDetail(failure.Err),
+	}
+}
@@ -0,0 +1,63 @@
+package hotel
+
+import (
+	"lite-api/internal/dto"
+	liteapierrors "lite-api/internal/errors"
+	"lite-api/internal/supplier"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomInfos(t *testing.T) {
+	from := time.Date(2024, 7, 14, 23, 59, 0, 0, time.UTC)
+	rooms := supplier.Rooms{{
+		Code: "DBL.ST",
+		Name: "DOUBLE STANDARD",
+		Rates: supplier.Rates{{
+			RateKey:              "a",
+			RateClass:            "NOR",
+			BoardCode:            "BB",
+			Refundable:           true,
+			Allotment:            3,
+			Net:                  120.5,
+			CancellationPolicies: supplier.CancellationPolicies{{Amount: 60.25, From: from}},
+			Taxes:                supplier.Taxes{{Amount: 4.4, Currency: "EUR"}},
+		}},
+	}}
+
+	require.Equal(t, dto.RoomInfos{{
+		Code: "DBL.ST",
+		Name: "DOUBLE STANDARD",
+		Rates: dto.RateInfos{{
+			RateKey:              "a",
+			RateClass:            "NOR",
+			BoardCode:            "BB",
+			Refundable:           true,
+			Allotment:            3,
+			Price:                120.5,
+			CancellationPolicies: dto.CancellationPolicies{{Amount: 60.25, From: from}},
+			Taxes:                dto.TaxInfos{{Amount: 4.4, Currency: "EUR"}},
+		}},
+	}}, roomInfos(rooms))
+}
+
+func TestBatchFailure(t *testing.T) {
+	upstreamErr := liteapierrors.NewUpstreamErr(http.StatusServiceUnavailable, "Service Unavailable", "internal server error")
+	failure := supplier.Failure{Batch: 2, HotelIds: []int{168, 264}, Err: upstreamErr}
+
+	require.Equal(t, dto.BatchFailure{
+		Supplier: "hotelbeds",
+		Batch:    2,
+		HotelIds: []int{168, 264},
+		Error: dto.ErrorDetail{
+			Code:           string(liteapierrors.KindSupplierUnavailable),
+			Message:        "internal server error",
+			Retryable:      true,
+			SupplierCode:   "Service Unavailable",
+			SupplierStatus: http.StatusServiceUnavailable,
+		},
+	}, batchFailure("hotelbeds", failure))
+}
@@ -40,6 +40,96 @@ func (m *MockHotelService) EXPECT() *MockHotelServiceMockRecorder {
 	return m.recorder
 }
 
+// Book mocks base method.
+func (m *MockHotelService) Book(ctx context.Context, request dto.BookingRequest) (dto.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", ctx, request)
+	ret0, _ := ret[0].(dto.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockHotelServiceMockRecorder) Book(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockHotelService)(nil).Book), ctx, request)
+}
+
+// BookingDetail mocks base method.
+func (m *MockHotelService) BookingDetail(ctx context.Context, request dto.BookingDetailRequest) (dto.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookingDetail", ctx, request)
+	ret0, _ := ret[0].(dto.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookingDetail indicates an expected call of BookingDetail.
+func (mr *MockHotelServiceMockRecorder) BookingDetail(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookingDetail", reflect.TypeOf((*MockHotelService)(nil).BookingDetail), ctx, request)
+}
+
+// CancelBooking mocks base method.
+func (m *MockHotelService) CancelBooking(ctx context.Context, request dto.CancelBookingRequest) (dto.BookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, request)
+	ret0, _ := ret[0].(dto.BookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockHotelServiceMockRecorder) CancelBooking(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockHotelService)(nil).CancelBooking), ctx, request)
+}
+
+// CheckRate mocks base method.
+func (m *MockHotelService) CheckRate(ctx context.Context, request dto.CheckRateRequest) (dto.CheckRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRate", ctx, request)
+	ret0, _ := ret[0].(dto.CheckRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRate indicates an expected call of CheckRate.
+func (mr *MockHotelServiceMockRecorder) CheckRate(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRate", reflect.TypeOf((*MockHotelService)(nil).CheckRate), ctx, request)
+}
+
+// HotelContent mocks base method.
+func (m *MockHotelService) HotelContent(ctx context.Context, request dto.HotelContentRequest) (dto.HotelContentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HotelContent", ctx, request)
+	ret0, _ := ret[0].(dto.HotelContentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HotelContent indicates an expected call of HotelContent.
+func (mr *MockHotelServiceMockRecorder) HotelContent(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HotelContent", reflect.TypeOf((*MockHotelService)(nil).HotelContent), ctx, request)
+}
+
+// MatchPricingRule mocks base method.
+func (m *MockHotelService) MatchPricingRule(ctx context.Context, request dto.PricingMatchRequest) (dto.PricingMatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchPricingRule", ctx, request)
+	ret0, _ := ret[0].(dto.PricingMatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchPricingRule indicates an expected call of MatchPricingRule.
+func (mr *MockHotelServiceMockRecorder) MatchPricingRule(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchPricingRule", reflect.TypeOf((*MockHotelService)(nil).MatchPricingRule), ctx, request)
+}
+
 // Search mocks base method.
 func (m *MockHotelService) Search(ctx context.Context, request dto.SearchRequest) (dto.SearchResponse, error) {
 	m.ctrl.T.Helper()
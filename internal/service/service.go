@@ -11,4 +11,16 @@ import (
 type HotelService interface {
 	// Search searches Hotelbeds with given request client.
 	Search(ctx context.Context, request dto.SearchRequest) (dto.SearchResponse, error)
+	// CheckRate re-prices the requested rates on Hotelbeds.
+	CheckRate(ctx context.Context, request dto.CheckRateRequest) (dto.CheckRateResponse, error)
+	// Book confirms a booking on Hotelbeds.
+	Book(ctx context.Context, request dto.BookingRequest) (dto.BookingResponse, error)
+	// BookingDetail fetches a booking from Hotelbeds by its reference.
+	BookingDetail(ctx context.Context, request dto.BookingDetailRequest) (dto.BookingResponse, error)
+	// CancelBooking cancels or simulates cancelling a booking on Hotelbeds.
+	CancelBooking(ctx context.Context, request dto.CancelBookingRequest) (dto.BookingResponse, error)
+	// HotelContent fetches the static data of a hotel from the Hotelbeds Content API.
+	HotelContent(ctx context.Context, request dto.HotelContentRequest) (dto.HotelContentResponse, error)
+	// MatchPricingRule returns the pricing rule marking up a net price and the resulting selling price.
+	MatchPricingRule(ctx context.Context, request dto.PricingMatchRequest) (dto.PricingMatchResponse, error)
 }
@@ -0,0 +1,97 @@
+package supplier
+
+import (
+	"context"
+	"lite-api/internal/client"
+	"sync"
+)
+
+// batchResult is the outcome of the availability request of a single batch.
+type batchResult struct {
+	res client.SearchResponse
+	err error
+}
+
+// searchBatches splits the hotel ids of searchReq into batches searched with bounded concurrency and merges their
+// results in batch order. Failed batches are reported as failures, err is only returned when every batch failed.
+// Destination searches are sent as a single request.
+func (h *Hotelbeds) searchBatches(ctx context.Context, searchReq client.SearchRequest) (client.SearchResponse, Failures, error) {
+	if searchReq.Hotels == nil {
+		res, err := h.cli.Search(ctx, searchReq)
+		return res, nil, err
+	}
+
+	batches := splitHotelIds(searchReq.Hotels.Hotel, h.cfg.BatchSize)
+	if len(batches) <= 1 {
+		res, err := h.cli.Search(ctx, searchReq)
+		return res, nil, err
+	}
+
+	results := make([]batchResult, len(batches))
+	sem := make(chan struct{}, h.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			batchReq := searchReq
+			batchReq.Hotels = &client.HotelIds{Hotel: batch}
+			results[i].res, results[i].err = h.cli.Search(ctx, batchReq)
+		}()
+	}
+
+	wg.Wait()
+
+	var (
+		merged   client.SearchResponse
+		failures Failures
+		firstErr error
+		merging  bool
+	)
+
+	for i, result := range results {
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
+			}
+
+			failures = append(failures, Failure{
+				Batch:    i,
+				HotelIds: batches[i],
+				Err:      result.err,
+			})
+
+			continue
+		}
+
+		if !merging {
+			merged = result.res
+			merged.Hotels.Hotels = append(client.Hotels{}, result.res.Hotels.Hotels...)
+			merging = true
+			continue
+		}
+
+		merged.Hotels.Hotels = append(merged.Hotels.Hotels, result.res.Hotels.Hotels...)
+		merged.Hotels.Total += result.res.Hotels.Total
+	}
+
+	if !merging {
+		return client.SearchResponse{}, nil, firstErr
+	}
+
+	return merged, failures, nil
+}
+
+// splitHotelIds splits ids into consecutive batches of at most size ids.
+func splitHotelIds(ids []int, size int) [][]int {
+	batches := make([][]int, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := min(start+size, len(ids))
+		batches = append(batches, ids[start:end])
+	}
+
+	return batches
+}
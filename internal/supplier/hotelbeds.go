@@ -0,0 +1,187 @@
+package supplier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lite-api/internal/client"
+	"strconv"
+)
+
+const (
+	// HotelbedsName is the name of the Hotelbeds supplier.
+	HotelbedsName = "hotelbeds"
+
+	defaultBatchSize   = 100
+	defaultConcurrency = 4
+)
+
+// HotelbedsConfig tunes how Hotelbeds is searched, zero values fall back to defaults.
+type HotelbedsConfig struct {
+	// BatchSize is the maximum number of hotel ids sent in a single availability request.
+	BatchSize int
+	// Concurrency is the maximum number of availability requests in flight for a single search.
+	Concurrency int
+}
+
+// Hotelbeds is the Supplier of the Hotelbeds APItude Booking API.
+type Hotelbeds struct {
+	cli client.HotelBeds
+	cfg HotelbedsConfig
+}
+
+// NewHotelbeds returns the Hotelbeds Supplier searching with cli.
+func NewHotelbeds(cli client.HotelBeds, cfg HotelbedsConfig) *Hotelbeds {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+
+	return &Hotelbeds{
+		cli: cli,
+		cfg: cfg,
+	}
+}
+
+// Name returns HotelbedsName.
+func (h *Hotelbeds) Name() string {
+	return HotelbedsName
+}
+
+// Capabilities returns every kind of search, Hotelbeds supports them all.
+func (h *Hotelbeds) Capabilities() Capabilities {
+	return Capabilities{CapabilityHotelIds, CapabilityDestination, CapabilityGeolocation}
+}
+
+// Search searches Hotelbeds in batches of hotel ids and converts the hotels whose minimum rate can be parsed.
+func (h *Hotelbeds) Search(ctx context.Context, req client.SearchRequest) (Availability, error) {
+	res, failures, err := h.searchBatches(ctx, req)
+	if err != nil {
+		return Availability{}, err
+	}
+
+	hotels := make(Hotels, 0, len(res.Hotels.Hotels))
+	for _, hotel := range res.Hotels.Hotels {
+		minRate, err := strconv.ParseFloat(hotel.MinRate, 64)
+		if err != nil {
+			continue
+		}
+
+		converted := Hotel{
+			ID:          strconv.Itoa(hotel.Code),
+			ContentCode: hotel.Code,
+			Name:        hotel.Name,
+			StarRating:  client.StarRating(hotel.CategoryCode),
+			Destination: hotel.DestinationCode,
+			Currency:    hotel.Currency,
+			MinRate:     minRate,
+			Rooms:       transformRooms(hotel.Rooms),
+		}
+
+		latitude, latErr := strconv.ParseFloat(hotel.Latitude, 64)
+		longitude, lonErr := strconv.ParseFloat(hotel.Longitude, 64)
+		if latErr == nil && lonErr == nil {
+			converted.Coordinates = &Coordinates{Latitude: latitude, Longitude: longitude}
+		}
+
+		hotels = append(hotels, converted)
+	}
+
+	response, err := json.Marshal(res)
+	if err != nil {
+		return Availability{}, err
+	}
+
+	return Availability{
+		Hotels:   hotels,
+		Failures: failures,
+		Response: response,
+	}, nil
+}
+
+// transformRooms converts Hotelbeds rooms and rates, skipping rates whose amounts cannot be parsed.
+func transformRooms(rooms client.Rooms) Rooms {
+	transformed := make(Rooms, 0, len(rooms))
+	for _, room := range rooms {
+		rates := make(Rates, 0, len(room.Rates))
+		for _, rate := range room.Rates {
+			transformedRate, err := transformRate(rate)
+			if err != nil {
+				continue
+			}
+
+			rates = append(rates, transformedRate)
+		}
+
+		transformed = append(transformed, Room{
+			Code:  room.Code,
+			Name:  room.Name,
+			Rates: rates,
+		})
+	}
+
+	return transformed
+}
+
+// transformRate converts a single Hotelbeds rate.
+func transformRate(rate client.Rate) (Rate, error) {
+	net, err := strconv.ParseFloat(rate.Net, 64)
+	if err != nil {
+		return Rate{}, fmt.Errorf("error parsing net price of rate %s: %w", rate.RateKey, err)
+	}
+
+	policies, err := TransformCancellationPolicies(rate.CancellationPolicies)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	taxes := make(Taxes, len(rate.Taxes.Taxes))
+	for i, tax := range rate.Taxes.Taxes {
+		amount, err := strconv.ParseFloat(tax.Amount, 64)
+		if err != nil {
+			return Rate{}, fmt.Errorf("error parsing tax amount of rate %s: %w", rate.RateKey, err)
+		}
+
+		taxes[i] = Tax{
+			Included: tax.Included,
+			Amount:   amount,
+			Currency: tax.Currency,
+		}
+	}
+
+	return Rate{
+		RateKey:              rate.RateKey,
+		RateClass:            rate.RateClass,
+		RateType:             rate.RateType,
+		BoardCode:            rate.BoardCode,
+		BoardName:            rate.BoardName,
+		PaymentType:          rate.PaymentType,
+		Refundable:           rate.RateClass != client.RateClassNonRefundable,
+		Allotment:            rate.Allotment,
+		Net:                  net,
+		CancellationPolicies: policies,
+		Taxes:                taxes,
+		TaxesIncluded:        rate.Taxes.AllIncluded,
+	}, nil
+}
+
+// TransformCancellationPolicies parses Hotelbeds cancellation policy amounts.
+func TransformCancellationPolicies(policies client.CancellationPolicies) (CancellationPolicies, error) {
+	transformed := make(CancellationPolicies, len(policies))
+	for i, policy := range policies {
+		amount, err := strconv.ParseFloat(policy.Amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cancellation amount: %w", err)
+		}
+
+		transformed[i] = CancellationPolicy{
+			Amount: amount,
+			From:   policy.From,
+		}
+	}
+
+	return transformed, nil
+}
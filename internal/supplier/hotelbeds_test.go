@@ -0,0 +1,244 @@
+package supplier
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"lite-api/internal/client"
+	hotelbedsmock "lite-api/internal/client/mock"
+	liteapierrors "lite-api/internal/errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSplitHotelIds(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []int
+		size int
+		want [][]int
+	}{
+		{
+			name: "empty",
+			ids:  nil,
+			size: 2,
+			want: [][]int{},
+		},
+		{
+			name: "single batch",
+			ids:  []int{1, 2},
+			size: 2,
+			want: [][]int{{1, 2}},
+		},
+		{
+			name: "uneven batches",
+			ids:  []int{1, 2, 3, 4, 5},
+			size: 2,
+			want: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, splitHotelIds(tt.ids, tt.size))
+		})
+	}
+}
+
+//go:embed testdata/hotelbeds_response.json
+var hotelbedsResponse []byte
+
+func TestHotelbeds_SearchBatches(t *testing.T) {
+	var cliResp client.SearchResponse
+	require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+
+	// responseFor returns the hotels of the test data response present in the batch request.
+	responseFor := func(req client.SearchRequest) client.SearchResponse {
+		res := cliResp
+		res.Hotels.Hotels = nil
+		for _, hotel := range cliResp.Hotels.Hotels {
+			for _, id := range req.Hotels.Hotel {
+				if hotel.Code == id {
+					res.Hotels.Hotels = append(res.Hotels.Hotels, hotel)
+				}
+			}
+		}
+
+		res.Hotels.Total = len(res.Hotels.Hotels)
+		return res
+	}
+
+	searchReq := client.SearchRequest{
+		Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-16"},
+		Occupancies: client.Occupancies{{Rooms: 1, Adults: 2}},
+		Hotels:      &client.HotelIds{Hotel: []int{168, 264, 77}},
+	}
+
+	ids := func(hotels Hotels) []string {
+		ids := make([]string, len(hotels))
+		for i, hotel := range hotels {
+			ids[i] = hotel.ID
+		}
+		return ids
+	}
+
+	t.Run("merges batches in order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(3).
+			DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+				require.Len(t, req.Hotels.Hotel, 1)
+				return responseFor(req), nil
+			})
+
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{BatchSize: 1, Concurrency: 3})
+		res, err := hotelbeds.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+		require.Empty(t, res.Failures)
+		require.Equal(t, []string{"168", "264", "77"}, ids(res.Hotels))
+	})
+
+	t.Run("reports partial failures", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		upstreamErr := liteapierrors.NewUpstreamErr(http.StatusServiceUnavailable, "Service Unavailable", "internal server error")
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+				if req.Hotels.Hotel[0] == 168 {
+					return client.SearchResponse{}, upstreamErr
+				}
+
+				return responseFor(req), nil
+			})
+
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{BatchSize: 2})
+		res, err := hotelbeds.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"77"}, ids(res.Hotels))
+		require.Equal(t, Failures{{Batch: 0, HotelIds: []int{168, 264}, Err: upstreamErr}}, res.Failures)
+	})
+
+	t.Run("destination searched in a single request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+				require.Nil(t, req.Hotels)
+				require.Equal(t, &client.Destination{Code: "PMI"}, req.Destination)
+				return cliResp, nil
+			})
+
+		destinationReq := searchReq
+		destinationReq.Hotels, destinationReq.Destination = nil, &client.Destination{Code: "PMI"}
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{BatchSize: 1})
+		res, err := hotelbeds.Search(context.Background(), destinationReq)
+		require.NoError(t, err)
+		require.Len(t, res.Hotels, 3)
+	})
+
+	t.Run("fails when every batch fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(3).Return(client.SearchResponse{}, assert.AnError)
+
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{BatchSize: 1})
+		res, err := hotelbeds.Search(context.Background(), searchReq)
+		require.ErrorIs(t, err, assert.AnError)
+		require.Zero(t, res)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+
+		var inFlight, maxInFlight atomic.Int32
+		cliMock.EXPECT().Search(gomock.Any(), gomock.Any()).Times(3).
+			DoAndReturn(func(_ context.Context, req client.SearchRequest) (client.SearchResponse, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					observed := maxInFlight.Load()
+					if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+				return responseFor(req), nil
+			})
+
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{BatchSize: 1, Concurrency: 1})
+		_, err := hotelbeds.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), maxInFlight.Load())
+	})
+}
+
+func TestHotelbeds_Search(t *testing.T) {
+	searchReq := client.SearchRequest{
+		Stay:        client.Stay{CheckIn: "2024-07-15", CheckOut: "2024-07-16"},
+		Occupancies: client.Occupancies{{Rooms: 1, Adults: 2}},
+		Hotels:      &client.HotelIds{Hotel: []int{168, 264, 77}},
+	}
+
+	t.Run("converts hotels", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		var cliResp client.SearchResponse
+		require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+		cliMock.EXPECT().Search(gomock.Any(), searchReq).Return(cliResp, nil)
+
+		hotelbeds := NewHotelbeds(cliMock, HotelbedsConfig{})
+		require.Equal(t, HotelbedsName, hotelbeds.Name())
+		require.True(t, hotelbeds.Capabilities().Has(CapabilityGeolocation))
+
+		res, err := hotelbeds.Search(context.Background(), searchReq)
+		require.NoError(t, err)
+		require.Len(t, res.Hotels, 3)
+
+		hotel := res.Hotels[2]
+		require.Equal(t, "77", hotel.ID)
+		require.Equal(t, 77, hotel.ContentCode)
+		require.Equal(t, "Barcelo Illetas Albatros", hotel.Name)
+		require.Equal(t, 4.0, hotel.StarRating)
+		require.Equal(t, "PMI", hotel.Destination)
+		require.Equal(t, &Coordinates{Latitude: 39.542286, Longitude: 2.595543}, hotel.Coordinates)
+		require.Equal(t, "EUR", hotel.Currency)
+		require.Equal(t, 336.24, hotel.MinRate)
+		require.Len(t, hotel.Rooms, 2)
+
+		expectedResponse, err := json.Marshal(cliResp)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedResponse), string(res.Response))
+	})
+
+	t.Run("skips hotels whose prices cannot be parsed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cliMock := hotelbedsmock.NewMockHotelBeds(ctrl)
+		var cliResp client.SearchResponse
+		require.NoError(t, json.Unmarshal(hotelbedsResponse, &cliResp))
+		cliResp.Hotels.Hotels[0].MinRate = "abc"
+		cliResp.Hotels.Hotels[1].Latitude = ""
+		cliResp.Hotels.Hotels[1].Rooms[0].Rates[0].Net = "abc"
+		cliMock.EXPECT().Search(gomock.Any(), searchReq).Return(cliResp, nil)
+
+		res, err := NewHotelbeds(cliMock, HotelbedsConfig{}).Search(context.Background(), searchReq)
+		require.NoError(t, err)
+		require.Len(t, res.Hotels, 2)
+		require.Equal(t, "264", res.Hotels[0].ID)
+		require.Nil(t, res.Hotels[0].Coordinates)
+		require.Len(t, res.Hotels[0].Rooms[0].Rates, len(cliResp.Hotels.Hotels[1].Rooms[0].Rates)-1)
+	})
+}
@@ -0,0 +1,220 @@
+package supplier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lite-api/internal/client"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrDuplicateSupplier is returned when registering a supplier under a name already taken.
+	ErrDuplicateSupplier = errors.New("supplier already registered")
+	// ErrUnknownSupplier is returned when enabling a supplier which is not registered.
+	ErrUnknownSupplier = errors.New("unknown supplier")
+	// ErrNoSupplier is returned when enabling no supplier at all.
+	ErrNoSupplier = errors.New("no supplier enabled")
+)
+
+// Capability is a kind of search a supplier supports.
+type Capability string
+
+const (
+	// CapabilityHotelIds searches a list of hotels of the supplier.
+	CapabilityHotelIds Capability = "hotel_ids"
+	// CapabilityDestination searches the hotels of a destination, optionally narrowed to a zone.
+	CapabilityDestination Capability = "destination"
+	// CapabilityGeolocation searches the hotels around a point.
+	CapabilityGeolocation Capability = "geolocation"
+)
+
+// Capabilities is a collection of Capability.
+type Capabilities []Capability
+
+// Has reports whether capability is one of c.
+func (c Capabilities) Has(capability Capability) bool {
+	return slices.Contains(c, capability)
+}
+
+// Supplier is a source of hotel availability.
+type Supplier interface {
+	// Name identifies the supplier in the configuration and in responses, e.g. hotelbeds.
+	Name() string
+	// Capabilities lists the kinds of search the supplier supports.
+	Capabilities() Capabilities
+	// Search returns the availability of the hotels of req, translated to the supplier API by the supplier. An
+	// error is only returned when nothing could be searched.
+	Search(ctx context.Context, req client.SearchRequest) (Availability, error)
+}
+
+// Availability is the result of a supplier search in lite-api's model.
+type Availability struct {
+	Hotels Hotels
+	// Failures lists the parts of a search which failed while others succeeded.
+	Failures Failures
+	// Response is the payload received from the supplier, for transparency.
+	Response json.RawMessage
+}
+
+// Hotels is a collection of Hotel.
+type Hotels []Hotel
+
+// Hotel is an available hotel of a supplier.
+type Hotel struct {
+	// ID is the code of the hotel at the supplier.
+	ID string
+	// ContentCode is the code of the hotel in the local content store, zero when it has none.
+	ContentCode int
+	Name        string
+	StarRating  float64
+	Destination string
+	// Coordinates is nil when the supplier does not report the location of the hotel.
+	Coordinates *Coordinates
+	Currency    string
+	// MinRate is the price of the cheapest rate of the hotel.
+	MinRate float64
+	// Rooms are the available rooms with their rates, priced in Currency.
+	Rooms Rooms
+}
+
+// Rooms is a collection of Room.
+type Rooms []Room
+
+// Room is an available room of a hotel.
+type Room struct {
+	Code  string
+	Name  string
+	Rates Rates
+}
+
+// Rates is a collection of Rate.
+type Rates []Rate
+
+// Rate is a bookable rate of a room.
+type Rate struct {
+	RateKey     string
+	RateClass   string
+	RateType    string
+	BoardCode   string
+	BoardName   string
+	PaymentType string
+	Refundable  bool
+	Allotment   int
+	// Net is the supplier price of the rate.
+	Net                  float64
+	CancellationPolicies CancellationPolicies
+	Taxes                Taxes
+	TaxesIncluded        bool
+}
+
+// CancellationPolicies is a collection of CancellationPolicy.
+type CancellationPolicies []CancellationPolicy
+
+// CancellationPolicy is the amount charged for cancelling a rate from a point in time.
+type CancellationPolicy struct {
+	Amount float64
+	From   time.Time
+}
+
+// Taxes is a collection of Tax.
+type Taxes []Tax
+
+// Tax is a tax applied on a rate.
+type Tax struct {
+	Included bool
+	Amount   float64
+	Currency string
+}
+
+// Failures is a collection of Failure.
+type Failures []Failure
+
+// Failure is a batch of the hotel ids of a search which failed.
+type Failure struct {
+	Batch    int
+	HotelIds []int
+	Err      error
+}
+
+// Coordinates locate a hotel.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Scope returns the capability a supplier needs to search req.
+func Scope(req client.SearchRequest) Capability {
+	switch {
+	case req.Destination != nil:
+		return CapabilityDestination
+	case req.Geolocation != nil:
+		return CapabilityGeolocation
+	default:
+		return CapabilityHotelIds
+	}
+}
+
+// Registry holds the suppliers available to the service, in registration order.
+type Registry struct {
+	suppliers []Supplier
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds s to the registry.
+func (r *Registry) Register(s Supplier) error {
+	if _, ok := r.Get(s.Name()); ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateSupplier, s.Name())
+	}
+
+	r.suppliers = append(r.suppliers, s)
+	return nil
+}
+
+// Get returns the supplier registered under name.
+func (r *Registry) Get(name string) (Supplier, bool) {
+	for _, s := range r.suppliers {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
+
+// Names returns the names of the registered suppliers.
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.suppliers))
+	for i, s := range r.suppliers {
+		names[i] = s.Name()
+	}
+
+	return names
+}
+
+// Enabled returns the suppliers registered under names, in the order of names.
+func (r *Registry) Enabled(names []string) ([]Supplier, error) {
+	if len(names) == 0 {
+		return nil, ErrNoSupplier
+	}
+
+	enabled := make([]Supplier, 0, len(names))
+	for _, name := range names {
+		s, ok := r.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, registered suppliers are %v", ErrUnknownSupplier, name, r.Names())
+		}
+
+		if !slices.Contains(enabled, s) {
+			enabled = append(enabled, s)
+		}
+	}
+
+	return enabled, nil
+}
@@ -0,0 +1,52 @@
+package supplier
+
+import (
+	"context"
+	"lite-api/internal/client"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// namedSupplier is a Supplier without availability.
+type namedSupplier string
+
+func (n namedSupplier) Name() string {
+	return string(n)
+}
+
+func (n namedSupplier) Capabilities() Capabilities {
+	return Capabilities{CapabilityHotelIds}
+}
+
+func (n namedSupplier) Search(context.Context, client.SearchRequest) (Availability, error) {
+	return Availability{}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(namedSupplier("hotelbeds")))
+	require.NoError(t, registry.Register(namedSupplier("expedia")))
+	require.ErrorIs(t, registry.Register(namedSupplier("hotelbeds")), ErrDuplicateSupplier)
+	require.Equal(t, []string{"hotelbeds", "expedia"}, registry.Names())
+
+	s, ok := registry.Get("expedia")
+	require.True(t, ok)
+	require.Equal(t, "expedia", s.Name())
+
+	enabled, err := registry.Enabled([]string{"expedia", "hotelbeds", "expedia"})
+	require.NoError(t, err)
+	require.Equal(t, []Supplier{namedSupplier("expedia"), namedSupplier("hotelbeds")}, enabled)
+
+	_, err = registry.Enabled([]string{"hotelbeds", "booking"})
+	require.ErrorIs(t, err, ErrUnknownSupplier)
+
+	_, err = registry.Enabled(nil)
+	require.ErrorIs(t, err, ErrNoSupplier)
+}
+
+func TestScope(t *testing.T) {
+	require.Equal(t, CapabilityHotelIds, Scope(client.SearchRequest{Hotels: &client.HotelIds{Hotel: []int{1}}}))
+	require.Equal(t, CapabilityDestination, Scope(client.SearchRequest{Destination: &client.Destination{Code: "PMI"}}))
+	require.Equal(t, CapabilityGeolocation, Scope(client.SearchRequest{Geolocation: &client.Geolocation{Radius: 5}}))
+}